@@ -1,6 +1,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
@@ -10,16 +12,93 @@ type Env struct {
 	SpotifyClientID     string `env:"SPOTIFY_CLIENT_ID" env-required:"true"`
 	SpotifyClientSecret string `env:"SPOTIFY_CLIENT_SECRET" env-required:"true"`
 
-	RedisAddr     string `env:"REDIS_ADDR" env-required:"true"`
+	// Cache backend: redis, memory (in-process, bounded in number of keys and
+	// in bytes), disk (a single file, kept across restarts) or noop
+	CacheBackend string `env:"CACHE_BACKEND" env-default:"redis"`
+
+	RedisAddr     string `env:"REDIS_ADDR"`
 	RedisPassword string `env:"REDIS_PASSWORD"`
 	RedisUsername string `env:"REDIS_USERNAME"`
 
+	// Compression of the values stored in Redis (gzip, zstd or none), skipped
+	// for values smaller than CACHE_COMPRESSION_MIN_SIZE bytes
+	CacheCompression        string `env:"CACHE_COMPRESSION" env-default:"gzip"`
+	CacheCompressionMinSize int    `env:"CACHE_COMPRESSION_MIN_SIZE" env-default:"512"`
+
+	MemoryCacheMaxEntries int   `env:"MEMORY_CACHE_MAX_ENTRIES" env-default:"100000"`
+	MemoryCacheMaxBytes   int64 `env:"MEMORY_CACHE_MAX_BYTES" env-default:"268435456"`
+
+	DiskCachePath string `env:"DISK_CACHE_PATH" env-default:"cache.db"`
+
+	// Prefix of the cache keys, and how often the keys of the previous
+	// schema versions and generations are deleted from Redis (0 disables it)
+	CacheNamespace       string        `env:"CACHE_NAMESPACE" env-default:"spotify"`
+	CacheJanitorInterval time.Duration `env:"CACHE_JANITOR_INTERVAL" env-default:"1h"`
+
 	Port string `env:"PORT" env-default:"1323"`
 
+	// ISO 3166-1 alpha-2 country code used when a search doesn't specify
+	// a market, either explicitly or through Accept-Language
+	DefaultMarket string `env:"DEFAULT_MARKET"`
+
+	// Maximum number of concurrent Spotify searches of a batch search
+	BatchConcurrency int `env:"BATCH_CONCURRENCY" env-default:"4"`
+
+	// Whether /resolve follows spotify.link short links, which makes an HTTP
+	// request to spotify.link on each cache miss, and how long it waits for
+	// their redirect
+	ShortLinksEnabled bool          `env:"SHORT_LINKS_ENABLED" env-default:"true"`
+	ShortLinkTimeout  time.Duration `env:"SHORT_LINK_TIMEOUT" env-default:"5s"`
+
+	// Maximum number of tracks returned for an album, fetched 50 at a time
+	MaxAlbumTracks int `env:"MAX_ALBUM_TRACKS" env-default:"500"`
+
+	// How long suggestions wait for a newer query of the same client before
+	// searching Spotify
+	SuggestDebounce time.Duration `env:"SUGGEST_DEBOUNCE" env-default:"150ms"`
+
+	// Search types whose queries are cached without their accents, so that
+	// "Beyonce" and "Beyoncé" share their cache entry
+	QueryFoldDiacritics []string `env:"QUERY_FOLD_DIACRITICS" env-separator:","`
+
+	// Cache TTLs of the search results, of the items fetched by ID and of the
+	// other resources (top-tracks, artist-albums, related-artists,
+	// album-tracks, identifier, short-link and suggest), as type:ttl pairs,
+	// and of the requests without results. All TTLs are randomly shortened or
+	// lengthened by up to CACHE_TTL_JITTER (a fraction below 1), then capped
+	// to MAX_CACHE_TTL
+	SearchTTLs       map[string]time.Duration `env:"SEARCH_TTLS" env-separator:"," env-default:"album:168h,track:168h"`
+	IDTTLs           map[string]time.Duration `env:"ID_TTLS" env-separator:","`
+	ResourceTTLs     map[string]time.Duration `env:"RESOURCE_TTLS" env-separator:","`
+	NegativeCacheTTL time.Duration            `env:"NEGATIVE_CACHE_TTL" env-default:"15m"`
+	CacheTTLJitter   float64                  `env:"CACHE_TTL_JITTER" env-default:"0.1"`
+	MaxCacheTTL      time.Duration            `env:"MAX_CACHE_TTL" env-default:"720h"`
+
+	// How long expired search results and resources are still served, with
+	// an X-Cache: STALE header, while they are refreshed in the background
+	StaleTTL time.Duration `env:"STALE_TTL" env-default:"24h"`
+
+	// In-process cache in front of Redis (only), bounded in number of keys
+	// and in bytes, whose values are kept for at most L1_CACHE_TTL and
+	// evicted by all instances when one of them writes them
+	L1CacheEnabled    bool          `env:"L1_CACHE_ENABLED" env-default:"true"`
+	L1CacheMaxEntries int           `env:"L1_CACHE_MAX_ENTRIES" env-default:"10000"`
+	L1CacheMaxBytes   int64         `env:"L1_CACHE_MAX_BYTES" env-default:"67108864"`
+	L1CacheTTL        time.Duration `env:"L1_CACHE_TTL" env-default:"1m"`
+
+	// Whether identical cache misses are also coalesced across instances,
+	// with a lock in Redis, rather than only within each instance
+	DistributedCoalescing bool `env:"DISTRIBUTED_COALESCING" env-default:"false"`
+
+	// Networks (CIDRs) whose clients can bypass the cached requests without
+	// results with Cache-Control: no-cache
+	TrustedNetworks []string `env:"TRUSTED_NETWORKS" env-separator:","`
+
 	LogFormat string `env:"LOG_FORMAT" env-default:"json"`
 	LogLevel  string `env:"LOG_LEVEL" env-default:"info"`
 
 	TracingEnabled bool   `env:"TRACING_ENABLED" env-default:"true"`
+	MetricsEnabled bool   `env:"METRICS_ENABLED" env-default:"true"`
 	OTLPEndpoint   string `env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
 }
 
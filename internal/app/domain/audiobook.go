@@ -0,0 +1,16 @@
+package domain
+
+type Audiobook struct {
+	Object
+	Authors          []string `json:"authors"`
+	Narrators        []string `json:"narrators"`
+	Publisher        string   `json:"publisher"`
+	Description      string   `json:"description"`
+	Edition          string   `json:"edition"`
+	Images           []Image  `json:"images"`
+	Explicit         bool     `json:"explicit"`
+	Languages        []string `json:"languages"`
+	MediaType        string   `json:"media_type"`
+	TotalChapters    int      `json:"total_chapters"`
+	AvailableMarkets []string `json:"available_markets"`
+}
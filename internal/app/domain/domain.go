@@ -0,0 +1,111 @@
+// Package domain defines the objects returned by the proxy. They are mapped
+// from Spotify's API objects so that the response schema doesn't depend on
+// the client library. Any change to their JSON shape must bump SchemaVersion.
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const SchemaVersion = 2
+
+// NotFoundCacheValue is cached in place of a payload when Spotify had nothing
+// for a request. It starts with a NUL byte, so it can't be mistaken for JSON.
+const NotFoundCacheValue = "\x00not-found"
+
+// Item is any object that can be returned by a search.
+type Item interface {
+	ObjectType() string
+	ObjectID() string
+	ObjectName() string
+}
+
+// Object holds the fields shared by all Spotify objects.
+type Object struct {
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	Name         string            `json:"name"`
+	URI          string            `json:"uri"`
+	ExternalURLs map[string]string `json:"external_urls"`
+}
+
+func (o Object) ObjectType() string { return o.Type }
+func (o Object) ObjectID() string   { return o.ID }
+func (o Object) ObjectName() string { return o.Name }
+
+type Image struct {
+	URL    string `json:"url"`
+	Height int    `json:"height"`
+	Width  int    `json:"width"`
+}
+
+type Followers struct {
+	Total int `json:"total"`
+}
+
+// NewItem returns an empty item of the given type.
+func NewItem(itemType string) (Item, error) {
+	switch itemType {
+	case "artist":
+		return &Artist{}, nil
+	case "album":
+		return &Album{}, nil
+	case "track":
+		return &Track{}, nil
+	case "playlist":
+		return &Playlist{}, nil
+	case "show":
+		return &Show{}, nil
+	case "episode":
+		return &Episode{}, nil
+	case "audiobook":
+		return &Audiobook{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown item type %q", itemType)
+}
+
+// UnmarshalItem decodes an item into its concrete type, based on its type
+// field.
+func UnmarshalItem(data []byte) (Item, error) {
+	var object struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	item, err := NewItem(object.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Items is a list of items of any type, which are decoded into their concrete
+// types.
+type Items []Item
+
+func (items *Items) UnmarshalJSON(data []byte) error {
+	var rawItems []json.RawMessage
+	if err := json.Unmarshal(data, &rawItems); err != nil {
+		return err
+	}
+
+	decoded := make(Items, 0, len(rawItems))
+	for _, rawItem := range rawItems {
+		item, err := UnmarshalItem(rawItem)
+		if err != nil {
+			return err
+		}
+		decoded = append(decoded, item)
+	}
+
+	*items = decoded
+	return nil
+}
@@ -0,0 +1,167 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockItem is an autogenerated mock type for the Item type
+type MockItem struct {
+	mock.Mock
+}
+
+type MockItem_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockItem) EXPECT() *MockItem_Expecter {
+	return &MockItem_Expecter{mock: &_m.Mock}
+}
+
+// ObjectID provides a mock function with given fields:
+func (_m *MockItem) ObjectID() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ObjectID")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockItem_ObjectID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObjectID'
+type MockItem_ObjectID_Call struct {
+	*mock.Call
+}
+
+// ObjectID is a helper method to define mock.On call
+func (_e *MockItem_Expecter) ObjectID() *MockItem_ObjectID_Call {
+	return &MockItem_ObjectID_Call{Call: _e.mock.On("ObjectID")}
+}
+
+func (_c *MockItem_ObjectID_Call) Run(run func()) *MockItem_ObjectID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockItem_ObjectID_Call) Return(_a0 string) *MockItem_ObjectID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockItem_ObjectID_Call) RunAndReturn(run func() string) *MockItem_ObjectID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ObjectName provides a mock function with given fields:
+func (_m *MockItem) ObjectName() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ObjectName")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockItem_ObjectName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObjectName'
+type MockItem_ObjectName_Call struct {
+	*mock.Call
+}
+
+// ObjectName is a helper method to define mock.On call
+func (_e *MockItem_Expecter) ObjectName() *MockItem_ObjectName_Call {
+	return &MockItem_ObjectName_Call{Call: _e.mock.On("ObjectName")}
+}
+
+func (_c *MockItem_ObjectName_Call) Run(run func()) *MockItem_ObjectName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockItem_ObjectName_Call) Return(_a0 string) *MockItem_ObjectName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockItem_ObjectName_Call) RunAndReturn(run func() string) *MockItem_ObjectName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ObjectType provides a mock function with given fields:
+func (_m *MockItem) ObjectType() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ObjectType")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockItem_ObjectType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObjectType'
+type MockItem_ObjectType_Call struct {
+	*mock.Call
+}
+
+// ObjectType is a helper method to define mock.On call
+func (_e *MockItem_Expecter) ObjectType() *MockItem_ObjectType_Call {
+	return &MockItem_ObjectType_Call{Call: _e.mock.On("ObjectType")}
+}
+
+func (_c *MockItem_ObjectType_Call) Run(run func()) *MockItem_ObjectType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockItem_ObjectType_Call) Return(_a0 string) *MockItem_ObjectType_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockItem_ObjectType_Call) RunAndReturn(run func() string) *MockItem_ObjectType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockItem creates a new instance of MockItem. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockItem(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockItem {
+	mock := &MockItem{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
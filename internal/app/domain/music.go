@@ -0,0 +1,43 @@
+package domain
+
+type SimpleArtist struct {
+	Object
+}
+
+type Artist struct {
+	Object
+	Genres     []string  `json:"genres"`
+	Images     []Image   `json:"images"`
+	Popularity int       `json:"popularity"`
+	Followers  Followers `json:"followers"`
+}
+
+type SimpleAlbum struct {
+	Object
+	AlbumType            string         `json:"album_type"`
+	Artists              []SimpleArtist `json:"artists"`
+	Images               []Image        `json:"images"`
+	ReleaseDate          string         `json:"release_date"`
+	ReleaseDatePrecision string         `json:"release_date_precision"`
+	AvailableMarkets     []string       `json:"available_markets"`
+}
+
+type Album struct {
+	SimpleAlbum
+	Popularity  int               `json:"popularity,omitempty"`
+	ExternalIDs map[string]string `json:"external_ids,omitempty"`
+}
+
+type Track struct {
+	Object
+	Album            SimpleAlbum       `json:"album"`
+	Artists          []SimpleArtist    `json:"artists"`
+	DiscNumber       int               `json:"disc_number"`
+	TrackNumber      int               `json:"track_number"`
+	DurationMs       int               `json:"duration_ms"`
+	Explicit         bool              `json:"explicit"`
+	Popularity       int               `json:"popularity"`
+	PreviewURL       string            `json:"preview_url"`
+	ExternalIDs      map[string]string `json:"external_ids"`
+	AvailableMarkets []string          `json:"available_markets"`
+}
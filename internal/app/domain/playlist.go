@@ -0,0 +1,16 @@
+package domain
+
+type User struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+}
+
+type Playlist struct {
+	Object
+	Description   string  `json:"description"`
+	Owner         User    `json:"owner"`
+	Images        []Image `json:"images"`
+	Collaborative bool    `json:"collaborative"`
+	Public        bool    `json:"public"`
+	TotalTracks   int     `json:"total_tracks"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+type Show struct {
+	Object
+	Description      string   `json:"description"`
+	Publisher        string   `json:"publisher"`
+	Images           []Image  `json:"images"`
+	Explicit         bool     `json:"explicit"`
+	Languages        []string `json:"languages"`
+	MediaType        string   `json:"media_type"`
+	AvailableMarkets []string `json:"available_markets"`
+}
+
+type Episode struct {
+	Object
+	Description          string   `json:"description"`
+	Images               []Image  `json:"images"`
+	DurationMs           int      `json:"duration_ms"`
+	Explicit             bool     `json:"explicit"`
+	Languages            []string `json:"languages"`
+	ReleaseDate          string   `json:"release_date"`
+	ReleaseDatePrecision string   `json:"release_date_precision"`
+	AudioPreviewURL      string   `json:"audio_preview_url"`
+}
@@ -0,0 +1,73 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultMaxAlbumTracks is enough for most box sets, which take one upstream
+// request per 50 tracks.
+const DefaultMaxAlbumTracks = 500
+
+// Tracklist is the tracks of an album. Total is the number of tracks of the
+// album, which is larger than len(Tracks) when they were truncated.
+type Tracklist struct {
+	Tracks    []domain.Item `json:"tracks"`
+	Total     int           `json:"total"`
+	Truncated bool          `json:"truncated"`
+}
+
+// UnmarshalJSON decodes the tracks into domain.Track, so that a cached
+// tracklist is encoded exactly like the tracklist it was built from.
+func (t *Tracklist) UnmarshalJSON(data []byte) error {
+	type tracklist Tracklist
+	var decoded struct {
+		tracklist
+		Tracks domain.Items `json:"tracks"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*t = Tracklist(decoded.tracklist)
+	t.Tracks = decoded.Tracks
+	return nil
+}
+
+// AlbumTracks returns the whole tracklist of an album, up to
+// Config.MaxAlbumTracks tracks.
+func (s SpotifySearchService) AlbumTracks(ctx context.Context, id string, market string) (*Tracklist, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.AlbumTracks")
+	defer span.End()
+
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	market, err := s.market(market)
+	if err != nil {
+		return nil, err
+	}
+
+	maxTracks := s.config.MaxAlbumTracks
+	if maxTracks <= 0 {
+		maxTracks = DefaultMaxAlbumTracks
+	}
+
+	span.SetAttributes(
+		attribute.String("id", id),
+		attribute.String("market", market),
+		attribute.Int("max_tracks", maxTracks),
+	)
+
+	// The cap is part of the key so that changing it doesn't serve
+	// tracklists truncated differently
+	key := s.keyspace.key(fmt.Sprintf("album-tracks:%s:%d:%s", market, maxTracks, id))
+	return cached(ctx, s, key, s.resourceTTL("album-tracks"), func(ctx context.Context) (*Tracklist, error) {
+		return s.spotifyClient.GetAlbumTracks(ctx, id, market, maxTracks)
+	})
+}
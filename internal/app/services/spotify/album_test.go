@@ -0,0 +1,131 @@
+package spotify_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifySearchService_AlbumTracks(t *testing.T) {
+	fancy := newItemWithID(t, "track", fancyID, "FANCY")
+
+	tests := []struct {
+		name        string
+		config      spotify.Config
+		id          string
+		market      string
+		setup       func(client *mocks.MockSpotifyClient, cache *mocks.MockCache)
+		expected    *spotify.Tracklist
+		expectedErr error
+	}{
+		{
+			name:   "cache miss",
+			id:     fancyID,
+			market: "kr",
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				key := "spotify:v2:g0:album-tracks:KR:500:" + fancyID
+				cache.On("Get", mock.Anything, key).
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				client.On("GetAlbumTracks", mock.Anything, fancyID, "KR", spotify.DefaultMaxAlbumTracks).
+					Return(&spotify.Tracklist{Tracks: []domain.Item{fancy}, Total: 1}, nil).
+					Once()
+				cache.On("Set", mock.Anything, key, mock.Anything, time.Hour*24*7).
+					Return(nil).
+					Once()
+			},
+			expected: &spotify.Tracklist{Tracks: []domain.Item{fancy}, Total: 1},
+		},
+		{
+			name:   "configured cap",
+			config: spotify.Config{MaxAlbumTracks: 100},
+			id:     fancyID,
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				key := "spotify:v2:g0:album-tracks::100:" + fancyID
+				cache.On("Get", mock.Anything, key).
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				client.On("GetAlbumTracks", mock.Anything, fancyID, "", 100).
+					Return(&spotify.Tracklist{Tracks: []domain.Item{fancy}, Total: 120, Truncated: true}, nil).
+					Once()
+				cache.On("Set", mock.Anything, key, mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+			},
+			expected: &spotify.Tracklist{Tracks: []domain.Item{fancy}, Total: 120, Truncated: true},
+		},
+		{
+			name: "cache hit",
+			id:   fancyID,
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("Get", mock.Anything, "spotify:v2:g0:album-tracks::500:"+fancyID).
+					Return(`{"tracks": [{"type": "track", "id": "`+fancyID+`", "name": "FANCY"}], "total": 1, "truncated": false}`, nil).
+					Once()
+			},
+			expected: &spotify.Tracklist{Tracks: []domain.Item{fancy}, Total: 1},
+		},
+		{
+			name: "unknown album",
+			id:   fancyID,
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("Get", mock.Anything, mock.Anything).
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				client.On("GetAlbumTracks", mock.Anything, fancyID, "", spotify.DefaultMaxAlbumTracks).
+					Return(nil, spotify.ErrNotFound).
+					Once()
+			},
+			expectedErr: spotify.ErrNotFound,
+		},
+		{
+			name:        "invalid id",
+			id:          "fancy",
+			expectedErr: spotify.ErrInvalidID,
+		},
+		{
+			name:        "invalid market",
+			id:          fancyID,
+			market:      "KOR",
+			expectedErr: spotify.ErrInvalidMarket,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedSpotifyClient := &mocks.MockSpotifyClient{}
+			mockedCache := &mocks.MockCache{}
+			t.Cleanup(func() {
+				mockedCache.AssertExpectations(t)
+				mockedSpotifyClient.AssertExpectations(t)
+			})
+
+			s := spotify.New(otel.Tracer("test"), mockedSpotifyClient, mockedCache, tt.config)
+
+			if tt.setup != nil {
+				tt.setup(mockedSpotifyClient, mockedCache)
+			}
+
+			tracklist, err := s.AlbumTracks(context.Background(), tt.id, tt.market)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expected, err := json.Marshal(tt.expected)
+			require.NoError(t, err)
+			actual, err := json.Marshal(tracklist)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AlbumGroups are the groups the albums of an artist can be filtered by.
+var AlbumGroups = []string{"album", "single", "appears_on", "compilation"}
+
+// profileAlbumGroups leaves out the albums an artist only appears on, which
+// are mostly compilations of other artists.
+var profileAlbumGroups = []string{"album", "single"}
+
+// Spotify requires a market for top tracks.
+const topTracksFallbackMarket = "US"
+
+// ArtistProfile is an artist with its top tracks, first albums and related
+// artists.
+type ArtistProfile struct {
+	Artist    domain.Item   `json:"artist"`
+	TopTracks []domain.Item `json:"top_tracks"`
+	Albums    *SearchPage   `json:"albums"`
+	Related   []domain.Item `json:"related"`
+	// Errors are the errors of the parts that couldn't be fetched, keyed by
+	// their JSON name. These parts are nil.
+	Errors map[string]error `json:"-"`
+}
+
+// ArtistTopTracks returns the 10 most popular tracks of an artist in the
+// market.
+func (s SpotifySearchService) ArtistTopTracks(ctx context.Context, id string, market string) ([]domain.Item, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.ArtistTopTracks")
+	defer span.End()
+
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	market, err := s.market(market)
+	if err != nil {
+		return nil, err
+	}
+	if market == "" {
+		market = topTracksFallbackMarket
+	}
+
+	span.SetAttributes(
+		attribute.String("id", id),
+		attribute.String("market", market),
+	)
+
+	key := s.keyspace.key(fmt.Sprintf("artist-top-tracks:%s:%s", market, id))
+	tracks, err := cached(ctx, s, key, s.resourceTTL("top-tracks"), func(ctx context.Context) (domain.Items, error) {
+		return s.spotifyClient.GetArtistTopTracks(ctx, id, market)
+	})
+	return tracks, err
+}
+
+// ArtistAlbums returns a page of the albums of an artist, only including the
+// given groups if any.
+func (s SpotifySearchService) ArtistAlbums(ctx context.Context, id string, includeGroups []string, opts SearchOptions) (*SearchPage, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.ArtistAlbums")
+	defer span.End()
+
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	var groups []string
+	for _, group := range includeGroups {
+		if !slices.Contains(AlbumGroups, group) {
+			return nil, fmt.Errorf("%w: include_groups must be among %s", ErrInvalidFilter, strings.Join(AlbumGroups, ", "))
+		}
+		if !slices.Contains(groups, group) {
+			groups = append(groups, group)
+		}
+	}
+	// So that the same groups in a different order share the cache
+	slices.Sort(groups)
+
+	if opts.Limit == 0 {
+		opts.Limit = DefaultSearchLimit
+	}
+	if opts.Limit < 1 || opts.Limit > MaxSearchLimit || opts.Offset < 0 {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d and offset positive", ErrInvalidPagination, MaxSearchLimit)
+	}
+
+	market, err := s.market(opts.Market)
+	if err != nil {
+		return nil, err
+	}
+	opts.Market = market
+
+	span.SetAttributes(
+		attribute.String("id", id),
+		attribute.StringSlice("include_groups", groups),
+		attribute.Int("limit", opts.Limit),
+		attribute.Int("offset", opts.Offset),
+		attribute.String("market", opts.Market),
+	)
+
+	key := s.keyspace.key(fmt.Sprintf("artist-albums:%s:%s:%d:%d:%s", opts.Market, strings.Join(groups, ","), opts.Limit, opts.Offset, id))
+	return cached(ctx, s, key, s.resourceTTL("artist-albums"), func(ctx context.Context) (*SearchPage, error) {
+		return s.spotifyClient.GetArtistAlbums(ctx, id, groups, opts)
+	})
+}
+
+// RelatedArtists returns up to 20 artists similar to an artist.
+func (s SpotifySearchService) RelatedArtists(ctx context.Context, id string) ([]domain.Item, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.RelatedArtists")
+	defer span.End()
+
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("id", id))
+
+	key := s.keyspace.key(fmt.Sprintf("artist-related:%s", id))
+	artists, err := cached(ctx, s, key, s.resourceTTL("related-artists"), func(ctx context.Context) (domain.Items, error) {
+		return s.spotifyClient.GetRelatedArtists(ctx, id)
+	})
+	return artists, err
+}
+
+// ArtistProfile fetches an artist along with its top tracks, first albums and
+// related artists concurrently. Only the artist is required: the other parts
+// are left empty and reported in Errors if they fail.
+func (s SpotifySearchService) ArtistProfile(ctx context.Context, id string, market string) (*ArtistProfile, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.ArtistProfile")
+	defer span.End()
+
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	profile := &ArtistProfile{Errors: map[string]error{}}
+	var artistErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	// partial records the error of an optional part
+	partial := func(part string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		profile.Errors[part] = err
+		span.RecordError(err, trace.WithAttributes(attribute.String("part", part)))
+	}
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		profile.Artist, artistErr = s.GetByID(ctx, "artist", id, market)
+	}()
+	go func() {
+		defer wg.Done()
+		tracks, err := s.ArtistTopTracks(ctx, id, market)
+		if err != nil {
+			partial("top_tracks", err)
+			return
+		}
+		profile.TopTracks = tracks
+	}()
+	go func() {
+		defer wg.Done()
+		albums, err := s.ArtistAlbums(ctx, id, profileAlbumGroups, SearchOptions{Market: market})
+		if err != nil {
+			partial("albums", err)
+			return
+		}
+		profile.Albums = albums
+	}()
+	go func() {
+		defer wg.Done()
+		related, err := s.RelatedArtists(ctx, id)
+		if err != nil {
+			partial("related", err)
+			return
+		}
+		profile.Related = related
+	}()
+	wg.Wait()
+
+	if artistErr != nil {
+		return nil, artistErr
+	}
+
+	span.SetAttributes(attribute.Int("failed_parts", len(profile.Errors)))
+
+	return profile, nil
+}
+
+func validateID(id string) error {
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return nil
+}
+
+// cached returns the cached value of the key, or fetches and caches it.
+// Stale values are served while they are fetched again in the background,
+// and concurrent misses of the same key share the same fetch.
+func cached[T any](ctx context.Context, s SpotifySearchService, key string, ttl time.Duration, fetch func(ctx context.Context) (T, error)) (T, error) {
+	value, stale, err := cachedValue[T](ctx, s, key)
+	if err == nil {
+		if stale {
+			s.serveStale(ctx, key, func(ctx context.Context) error {
+				_, err := fetchAndCache(ctx, s, key, ttl, fetch)
+				return err
+			})
+		}
+		return value, nil
+	}
+	if !errors.Is(err, errNotCached) {
+		return value, err
+	}
+
+	lookup := func(ctx context.Context) (T, error) {
+		value, _, err := cachedValue[T](ctx, s, key)
+		return value, err
+	}
+	return coalesce(ctx, s, key, lookup, func(ctx context.Context) (T, error) {
+		return fetchAndCache(ctx, s, key, ttl, fetch)
+	})
+}
+
+// cachedValue returns the cached value of the key and whether it is stale,
+// ErrNoResultsFound for cached not-found outcomes, and errNotCached
+// otherwise.
+func cachedValue[T any](ctx context.Context, s SpotifySearchService, key string) (T, bool, error) {
+	var value T
+
+	val, err := s.cache.Get(ctx, key)
+	if err != nil || val == "" {
+		return value, false, errNotCached
+	}
+	if isNegative(ctx, val) {
+		return value, false, ErrNoResultsFound
+	}
+
+	payload, stale := decodeEntry(val)
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return value, false, errNotCached
+	}
+	return value, stale, nil
+}
+
+// fetchAndCache fetches and caches the value of the key. Errors of fetch
+// other than ErrNotFound and ErrNoResultsFound are Spotify client errors.
+// Only ErrNoResultsFound is cached, for the negative cache TTL.
+func fetchAndCache[T any](ctx context.Context, s SpotifySearchService, key string, ttl time.Duration, fetch func(ctx context.Context) (T, error)) (T, error) {
+	value, err := fetch(ctx)
+	if err != nil {
+		if errors.Is(err, ErrNoResultsFound) {
+			if err := s.cache.Set(ctx, key, []byte(domain.NotFoundCacheValue), s.ttl(s.negativeTTL())); err != nil {
+				trace.SpanFromContext(ctx).RecordError(err)
+			}
+			return value, err
+		}
+		if errors.Is(err, ErrNotFound) {
+			return value, err
+		}
+		return value, fmt.Errorf("%w: %s", ErrSpotifyClient, err.Error())
+	}
+
+	marshaledValue, err := json.Marshal(value)
+	if err != nil {
+		return value, err
+	}
+	ttl = s.ttl(ttl)
+	if err := s.cache.Set(ctx, key, s.encodeEntry(marshaledValue, ttl), s.keyTTL(ttl)); err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+
+	return value, nil
+}
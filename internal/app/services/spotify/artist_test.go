@@ -0,0 +1,271 @@
+package spotify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifySearchService_ArtistTopTracks(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+	t.Cleanup(func() {
+		mockedCache.AssertExpectations(t)
+		mockedSpotifyClient.AssertExpectations(t)
+	})
+
+	s := spotify.New(otel.Tracer("test"), mockedSpotifyClient, mockedCache, spotify.Config{})
+
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := s.ArtistTopTracks(context.Background(), "twice", "")
+		assert.ErrorIs(t, err, spotify.ErrInvalidID)
+	})
+
+	t.Run("cache miss falls back to the US market", func(t *testing.T) {
+		key := "spotify:v2:g0:artist-top-tracks:US:" + twiceID
+		fancy := newItemWithID(t, "track", fancyID, "FANCY")
+
+		mockedCache.On("Get", mock.Anything, key).
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedSpotifyClient.On("GetArtistTopTracks", mock.Anything, twiceID, "US").
+			Return([]domain.Item{fancy}, nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, key, mock.Anything, time.Hour*24).
+			Return(nil).
+			Once()
+
+		tracks, err := s.ArtistTopTracks(context.Background(), twiceID, "")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Item{fancy}, tracks)
+	})
+
+	t.Run("cache hit", func(t *testing.T) {
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist-top-tracks:KR:"+twiceID).
+			Return(`[{"type": "track", "id": "`+fancyID+`", "name": "FANCY"}]`, nil).
+			Once()
+
+		tracks, err := s.ArtistTopTracks(context.Background(), twiceID, "kr")
+		require.NoError(t, err)
+		require.Len(t, tracks, 1)
+		assert.Equal(t, "FANCY", tracks[0].ObjectName())
+	})
+}
+
+func TestSpotifySearchService_ArtistAlbums(t *testing.T) {
+	tests := []struct {
+		name          string
+		includeGroups []string
+		opts          spotify.SearchOptions
+		expectedKey   string
+		expectedOpts  spotify.SearchOptions
+		expectedErr   error
+	}{
+		{
+			name:         "defaults",
+			expectedKey:  "spotify:v2:g0:artist-albums:FR::20:0:" + twiceID,
+			expectedOpts: spotify.SearchOptions{Limit: 20, Market: "FR"},
+		},
+		{
+			name:          "groups are deduplicated and sorted",
+			includeGroups: []string{"single", "album", "single"},
+			opts:          spotify.SearchOptions{Limit: 5, Offset: 10, Market: "kr"},
+			expectedKey:   "spotify:v2:g0:artist-albums:KR:album,single:5:10:" + twiceID,
+			expectedOpts:  spotify.SearchOptions{Limit: 5, Offset: 10, Market: "KR"},
+		},
+		{
+			name:          "unknown group",
+			includeGroups: []string{"ep"},
+			expectedErr:   spotify.ErrInvalidFilter,
+		},
+		{
+			name:        "limit too high",
+			opts:        spotify.SearchOptions{Limit: 51},
+			expectedErr: spotify.ErrInvalidPagination,
+		},
+		{
+			name:        "negative offset",
+			opts:        spotify.SearchOptions{Offset: -1},
+			expectedErr: spotify.ErrInvalidPagination,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedSpotifyClient := &mocks.MockSpotifyClient{}
+			mockedCache := &mocks.MockCache{}
+			t.Cleanup(func() {
+				mockedCache.AssertExpectations(t)
+				mockedSpotifyClient.AssertExpectations(t)
+			})
+
+			s := spotify.New(otel.Tracer("test"), mockedSpotifyClient, mockedCache, spotify.Config{DefaultMarket: "FR"})
+
+			if tt.expectedErr != nil {
+				_, err := s.ArtistAlbums(context.Background(), twiceID, tt.includeGroups, tt.opts)
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			page := &spotify.SearchPage{Limit: tt.expectedOpts.Limit, Offset: tt.expectedOpts.Offset, Total: 1}
+			mockedCache.On("Get", mock.Anything, tt.expectedKey).
+				Return("", spotify.ErrCacheMiss).
+				Once()
+			mockedSpotifyClient.On("GetArtistAlbums", mock.Anything, twiceID, mock.Anything, tt.expectedOpts).
+				Return(page, nil).
+				Once()
+			mockedCache.On("Set", mock.Anything, tt.expectedKey, mock.Anything, time.Hour*24).
+				Return(nil).
+				Once()
+
+			result, err := s.ArtistAlbums(context.Background(), twiceID, tt.includeGroups, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, page, result)
+		})
+	}
+}
+
+func TestSpotifySearchService_RelatedArtists(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+	t.Cleanup(func() {
+		mockedCache.AssertExpectations(t)
+		mockedSpotifyClient.AssertExpectations(t)
+	})
+
+	s := spotify.New(otel.Tracer("test"), mockedSpotifyClient, mockedCache, spotify.Config{})
+
+	t.Run("unknown artist", func(t *testing.T) {
+		key := "spotify:v2:g0:artist-related:" + twiceID
+		mockedCache.On("Get", mock.Anything, key).
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedSpotifyClient.On("GetRelatedArtists", mock.Anything, twiceID).
+			Return(nil, spotify.ErrNotFound).
+			Once()
+
+		_, err := s.RelatedArtists(context.Background(), twiceID)
+		assert.ErrorIs(t, err, spotify.ErrNotFound)
+		assert.NotErrorIs(t, err, spotify.ErrSpotifyClient)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		key := "spotify:v2:g0:artist-related:" + ivesID
+		mockedCache.On("Get", mock.Anything, key).
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedSpotifyClient.On("GetRelatedArtists", mock.Anything, ivesID).
+			Return(nil, errors.New("timeout")).
+			Once()
+
+		_, err := s.RelatedArtists(context.Background(), ivesID)
+		assert.ErrorIs(t, err, spotify.ErrSpotifyClient)
+	})
+}
+
+func TestSpotifySearchService_ArtistProfile(t *testing.T) {
+	twice := newItemWithID(t, "artist", twiceID, "TWICE")
+	ives := newItemWithID(t, "artist", ivesID, "IVE")
+	fancy := newItemWithID(t, "track", fancyID, "FANCY")
+
+	setup := func(t *testing.T, relatedErr error) spotify.SpotifySearchService {
+		mockedSpotifyClient := &mocks.MockSpotifyClient{}
+		mockedCache := &mocks.MockCache{}
+		t.Cleanup(func() {
+			mockedCache.AssertExpectations(t)
+			mockedSpotifyClient.AssertExpectations(t)
+		})
+
+		mockedCache.On("MGet", mock.Anything, []string{"spotify:v2:g0:id:artist::" + twiceID}).
+			Return([]string{`{"type": "artist", "id": "` + twiceID + `", "name": "TWICE"}`}, nil).
+			Once()
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist-top-tracks:US:"+twiceID).
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedSpotifyClient.On("GetArtistTopTracks", mock.Anything, twiceID, "US").
+			Return([]domain.Item{fancy}, nil).
+			Once()
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist-albums::album,single:20:0:"+twiceID).
+			Return(`{"items": [], "limit": 20, "offset": 0, "total": 0}`, nil).
+			Once()
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist-related:"+twiceID).
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		if relatedErr != nil {
+			mockedSpotifyClient.On("GetRelatedArtists", mock.Anything, twiceID).
+				Return(nil, relatedErr).
+				Once()
+		} else {
+			mockedSpotifyClient.On("GetRelatedArtists", mock.Anything, twiceID).
+				Return([]domain.Item{ives}, nil).
+				Once()
+		}
+		mockedCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+
+		return newArtistService(mockedSpotifyClient, mockedCache)
+	}
+
+	t.Run("complete", func(t *testing.T) {
+		profile, err := setup(t, nil).ArtistProfile(context.Background(), twiceID, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, twice, profile.Artist)
+		assert.Equal(t, []domain.Item{fancy}, profile.TopTracks)
+		assert.Equal(t, 20, profile.Albums.Limit)
+		assert.Equal(t, []domain.Item{ives}, profile.Related)
+		assert.Empty(t, profile.Errors)
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		profile, err := setup(t, errors.New("timeout")).ArtistProfile(context.Background(), twiceID, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, twice, profile.Artist)
+		assert.Equal(t, []domain.Item{fancy}, profile.TopTracks)
+		assert.Nil(t, profile.Related)
+		require.Contains(t, profile.Errors, "related")
+		assert.ErrorIs(t, profile.Errors["related"], spotify.ErrSpotifyClient)
+	})
+
+	t.Run("unknown artist", func(t *testing.T) {
+		mockedSpotifyClient := &mocks.MockSpotifyClient{}
+		mockedCache := &mocks.MockCache{}
+
+		mockedCache.On("MGet", mock.Anything, mock.Anything).
+			Return([]string{""}, nil)
+		mockedCache.On("Get", mock.Anything, mock.Anything).
+			Return("", spotify.ErrCacheMiss)
+		mockedCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		mockedSpotifyClient.On("GetArtists", mock.Anything, []string{twiceID}).
+			Return([]domain.Item{nil}, nil)
+		mockedSpotifyClient.On("GetArtistTopTracks", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, spotify.ErrNotFound)
+		mockedSpotifyClient.On("GetArtistAlbums", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, spotify.ErrNotFound)
+		mockedSpotifyClient.On("GetRelatedArtists", mock.Anything, mock.Anything).
+			Return(nil, spotify.ErrNotFound)
+
+		_, err := newArtistService(mockedSpotifyClient, mockedCache).ArtistProfile(context.Background(), twiceID, "")
+		assert.ErrorIs(t, err, spotify.ErrNotFound)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := newArtistService(&mocks.MockSpotifyClient{}, &mocks.MockCache{}).ArtistProfile(context.Background(), "twice", "")
+		assert.ErrorIs(t, err, spotify.ErrInvalidID)
+	})
+}
+
+func newArtistService(client *mocks.MockSpotifyClient, cache *mocks.MockCache) spotify.SpotifySearchService {
+	return spotify.New(otel.Tracer("test"), client, cache, spotify.Config{})
+}
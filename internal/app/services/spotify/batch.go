@@ -0,0 +1,274 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MaxBatchSize is the maximum number of searches in a batch.
+const MaxBatchSize = 100
+
+// DefaultBatchConcurrency is the number of concurrent upstream searches of a
+// batch when the config doesn't set one.
+const DefaultBatchConcurrency = 4
+
+type BatchRequest struct {
+	Type    string
+	Query   string
+	Options SearchOptions
+	// List returns a page of results instead of the best match.
+	List  bool
+	Match MatchOptions
+}
+
+// BatchResult is either the best match, a page of results or the error of a
+// search.
+type BatchResult struct {
+	Match *Match
+	Page  *SearchPage
+	Err   error
+}
+
+type batchSearch struct {
+	searchType string
+	query      searchQuery
+	opts       SearchOptions
+}
+
+// BatchSearch runs several searches at once. Cached results are fetched in a
+// single round trip, and the others are searched concurrently. The results
+// are in the same order as the requests, and failed searches don't fail the
+// whole batch.
+func (s SpotifySearchService) BatchSearch(ctx context.Context, requests []BatchRequest) ([]BatchResult, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.BatchSearch")
+	defer span.End()
+
+	if len(requests) == 0 || len(requests) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: must contain between 1 and %d searches", ErrInvalidBatch, MaxBatchSize)
+	}
+
+	results := make([]BatchResult, len(requests))
+
+	// Identical searches share the same cache key, and are only searched once
+	keys := make([]string, len(requests))
+	searches := map[string]batchSearch{}
+	var uniqueKeys []string
+	for i, request := range requests {
+		search, err := s.batchSearch(request)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		keys[i] = s.searchCacheKey(search.searchType, search.query, search.opts)
+		if _, exists := searches[keys[i]]; !exists {
+			searches[keys[i]] = search
+			uniqueKeys = append(uniqueKeys, keys[i])
+		}
+	}
+
+	pages := s.cachedPages(ctx, uniqueKeys, searches)
+
+	var missingKeys []string
+	for _, key := range uniqueKeys {
+		if _, cached := pages[key]; !cached {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("searches", len(requests)),
+		attribute.Int("unique_searches", len(uniqueKeys)),
+		attribute.Int("cache_misses", len(missingKeys)),
+	)
+
+	errs := s.fetchPages(ctx, missingKeys, searches, pages)
+
+	for i, request := range requests {
+		if results[i].Err != nil {
+			continue
+		}
+		if err := errs[keys[i]]; err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		page := pages[keys[i]]
+		if request.List {
+			results[i].Page = page
+			continue
+		}
+
+		target := matchTarget(request.Query, request.Options.Filters, request.Type)
+		results[i].Match = bestMatch(page.Items, target, request.Match)
+		if results[i].Match == nil {
+			results[i].Err = ErrNoResultsFound
+		}
+	}
+
+	return results, nil
+}
+
+// batchSearch validates a request of a batch, and returns the search it
+// translates to.
+func (s SpotifySearchService) batchSearch(request BatchRequest) (batchSearch, error) {
+	if !slices.Contains(SearchTypes, request.Type) {
+		return batchSearch{}, fmt.Errorf("%w: %s", ErrInvalidQueryType, request.Type)
+	}
+
+	opts := request.Options
+	if !request.List {
+		if err := request.Match.validate(); err != nil {
+			return batchSearch{}, err
+		}
+		opts.Limit, opts.Offset = matchCandidates, 0
+	}
+
+	opts, err := s.searchOptions(opts)
+	if err != nil {
+		return batchSearch{}, err
+	}
+
+	query, err := buildQuery(request.Query, opts.Filters, []string{request.Type}, s.normalization(request.Type))
+	if err != nil {
+		return batchSearch{}, err
+	}
+
+	// The filters are part of the query from now on
+	opts.Filters = SearchFilters{}
+
+	return batchSearch{
+		searchType: request.Type,
+		query:      query,
+		opts:       opts,
+	}, nil
+}
+
+// cachedPages returns the cached pages of the keys, keyed by cache key.
+// Cached not-found outcomes are empty pages.
+func (s SpotifySearchService) cachedPages(ctx context.Context, keys []string, searches map[string]batchSearch) map[string]*SearchPage {
+	pages := make(map[string]*SearchPage, len(keys))
+	if len(keys) == 0 {
+		return pages
+	}
+
+	values, err := s.cache.MGet(ctx, keys)
+	if err != nil {
+		// Search everything again rather than failing the batch
+		trace.SpanFromContext(ctx).RecordError(err)
+		return pages
+	}
+
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		if isNegative(ctx, value) {
+			pages[keys[i]] = NewSearchPage(nil, 0, searches[keys[i]].opts)
+			continue
+		}
+
+		payload, stale := decodeEntry(value)
+		var cachedResult SearchPage
+		if err := json.Unmarshal(payload, &cachedResult); err == nil {
+			if stale {
+				search := searches[keys[i]]
+				s.refreshPage(ctx, keys[i], search.searchType, search.query, search.opts)
+			}
+			pages[keys[i]] = &cachedResult
+		}
+	}
+
+	return pages
+}
+
+// fetchPages searches for the keys with at most BatchConcurrency concurrent
+// upstream calls, adds the results to the pages and caches them. It returns
+// the errors keyed by cache key.
+func (s SpotifySearchService) fetchPages(ctx context.Context, keys []string, searches map[string]batchSearch, pages map[string]*SearchPage) map[string]error {
+	errs := map[string]error{}
+	if len(keys) == 0 {
+		return errs
+	}
+
+	concurrency := s.config.BatchConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	fetched := make([]*SearchPage, len(keys))
+	fetchErrs := make([]error, len(keys))
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, search batchSearch) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results, err := s.spotifyClient.Search(ctx, search.query.text, []string{search.searchType}, search.opts)
+			if err != nil {
+				fetchErrs[i] = fmt.Errorf("%w: %s", ErrSpotifyClient, err.Error())
+				return
+			}
+			fetched[i] = results[search.searchType]
+		}(i, searches[key])
+	}
+	wg.Wait()
+
+	// Grouped by type, as their TTLs may differ
+	toCache := map[string]map[string][]byte{}
+	notFound := map[string][]byte{}
+	for i, key := range keys {
+		if fetchErrs[i] != nil {
+			errs[key] = fetchErrs[i]
+			continue
+		}
+
+		page := fetched[i]
+		if page == nil || len(page.Items) == 0 {
+			notFound[key] = []byte(domain.NotFoundCacheValue)
+			pages[key] = NewSearchPage(nil, 0, searches[key].opts)
+			continue
+		}
+
+		marshaledResult, err := json.Marshal(page)
+		if err != nil {
+			errs[key] = err
+			continue
+		}
+		searchType := searches[key].searchType
+		if toCache[searchType] == nil {
+			toCache[searchType] = map[string][]byte{}
+		}
+		toCache[searchType][key] = marshaledResult
+		pages[key] = page
+	}
+
+	for _, searchType := range SearchTypes {
+		if len(toCache[searchType]) == 0 {
+			continue
+		}
+
+		if err := s.msetJittered(ctx, toCache[searchType], s.searchTTL(searchType), true); err != nil {
+			trace.SpanFromContext(ctx).RecordError(err)
+		}
+	}
+	if len(notFound) > 0 {
+		if err := s.msetJittered(ctx, notFound, s.negativeTTL(), false); err != nil {
+			trace.SpanFromContext(ctx).RecordError(err)
+		}
+	}
+
+	return errs
+}
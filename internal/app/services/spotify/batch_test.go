@@ -0,0 +1,128 @@
+package spotify_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifySearchService_BatchSearch(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+
+	s := spotify.New(
+		otel.Tracer("test"),
+		mockedSpotifyClient,
+		mockedCache,
+		spotify.Config{BatchConcurrency: 2},
+	)
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		_, err := s.BatchSearch(context.Background(), nil)
+		assert.ErrorIs(t, err, spotify.ErrInvalidBatch)
+
+		_, err = s.BatchSearch(context.Background(), make([]spotify.BatchRequest, spotify.MaxBatchSize+1))
+		assert.ErrorIs(t, err, spotify.ErrInvalidBatch)
+	})
+
+	t.Run("results in input order", func(t *testing.T) {
+		matchOpts := spotify.SearchOptions{Limit: 10}
+		listOpts := spotify.SearchOptions{Limit: 5}
+
+		mockedCache.On("MGet", mock.Anything, []string{
+			"spotify:v2:g0:artist:::10:0:twice",
+			"spotify:v2:g0:track:::5:0:fancy",
+			"spotify:v2:g0:album:::10:0:nothing",
+			"spotify:v2:g0:show:::10:0:podcast",
+		}).
+			Return([]string{`{"items": [{"type": "artist", "name": "TWICE"}], "total": 1}`, "", "", ""}, nil).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "FANCY", []string{"track"}, listOpts).
+			Return(map[string]*spotify.SearchPage{
+				"track": spotify.NewSearchPage([]domain.Item{newItem(t, "track", "FANCY")}, 1, listOpts),
+			}, nil).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "nothing", []string{"album"}, matchOpts).
+			Return(map[string]*spotify.SearchPage{}, nil).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "podcast", []string{"show"}, matchOpts).
+			Return(nil, errors.New("rate limited")).
+			Once()
+		mockedCache.On("MSet", mock.Anything, mock.MatchedBy(func(values map[string][]byte) bool {
+			_, ok := values["spotify:v2:g0:track:::5:0:fancy"]
+			return ok && len(values) == 1
+		}), time.Hour*24).
+			Return(nil).
+			Once()
+		mockedCache.On("MSet", mock.Anything, map[string][]byte{
+			"spotify:v2:g0:album:::10:0:nothing": []byte(domain.NotFoundCacheValue),
+		}, spotify.DefaultNegativeCacheTTL).
+			Return(nil).
+			Once()
+
+		results, err := s.BatchSearch(context.Background(), []spotify.BatchRequest{
+			{Type: "artist", Query: "TWICE"},
+			{Type: "track", Query: "FANCY", Options: spotify.SearchOptions{Limit: 5}, List: true},
+			{Type: "artist", Query: "TWICE"},
+			{Type: "album", Query: "nothing"},
+			{Type: "invalid", Query: "TWICE"},
+			{Type: "show", Query: "podcast"},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 6)
+
+		require.NoError(t, results[0].Err)
+		assert.Equal(t, "TWICE", results[0].Match.Item.ObjectName())
+		require.NoError(t, results[1].Err)
+		assert.Equal(t, 1, results[1].Page.Total)
+		assert.Equal(t, results[0], results[2])
+		assert.ErrorIs(t, results[3].Err, spotify.ErrNoResultsFound)
+		assert.ErrorIs(t, results[4].Err, spotify.ErrInvalidQueryType)
+		assert.ErrorIs(t, results[5].Err, spotify.ErrSpotifyClient)
+	})
+
+	t.Run("NaN minimum confidence", func(t *testing.T) {
+		results, err := s.BatchSearch(context.Background(), []spotify.BatchRequest{
+			{Type: "artist", Query: "TWICE", Match: spotify.MatchOptions{MinConfidence: math.NaN()}},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.ErrorIs(t, results[0].Err, spotify.ErrInvalidMinConfidence)
+	})
+
+	t.Run("cache error", func(t *testing.T) {
+		opts := spotify.SearchOptions{Limit: 10}
+
+		mockedCache.On("MGet", mock.Anything, []string{"spotify:v2:g0:artist:::10:0:twice"}).
+			Return(nil, errors.New("connection refused")).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"artist"}, opts).
+			Return(map[string]*spotify.SearchPage{
+				"artist": spotify.NewSearchPage([]domain.Item{newItem(t, "artist", "TWICE")}, 1, opts),
+			}, nil).
+			Once()
+		mockedCache.On("MSet", mock.Anything, mock.Anything, time.Hour*24).
+			Return(errors.New("connection refused")).
+			Once()
+
+		results, err := s.BatchSearch(context.Background(), []spotify.BatchRequest{
+			{Type: "artist", Query: "TWICE"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, results[0].Err)
+		assert.Equal(t, "TWICE", results[0].Match.Item.ObjectName())
+	})
+
+	mockedCache.AssertExpectations(t)
+	mockedSpotifyClient.AssertExpectations(t)
+}
@@ -0,0 +1,138 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// lockTTL bounds how long an instance holds the lock of a key, in case
+	// it dies while fetching it.
+	lockTTL = time.Second * 10
+	// lockWait is how long an instance waits for another one to cache a key
+	// before fetching it itself.
+	lockWait         = time.Second * 5
+	lockPollInterval = time.Millisecond * 100
+	// fetchTimeout bounds a coalesced fetch, as it is no longer bound to the
+	// request that started it.
+	fetchTimeout = time.Second * 30
+)
+
+// Scopes of the coalesced requests.
+const (
+	coalescedLocal       = "local"
+	coalescedDistributed = "distributed"
+)
+
+// errNotCached is returned by the cache lookups of coalesce when the key
+// isn't cached yet.
+var errNotCached = errors.New("not cached")
+
+// coalescer shares the upstream calls of concurrent identical cache misses.
+type coalescer struct {
+	group     singleflight.Group
+	coalesced metric.Int64Counter
+}
+
+func newCoalescer(meter metric.Meter) *coalescer {
+	coalesced, err := meter.Int64Counter(
+		"spotify.coalesced_requests",
+		metric.WithDescription("Cache misses that reused the upstream call of a concurrent identical miss"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		coalesced = noop.Int64Counter{}
+	}
+
+	return &coalescer{coalesced: coalesced}
+}
+
+// record reports a caller that reused the upstream call of another one.
+func (c *coalescer) record(ctx context.Context, scope string) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Bool("coalesced", true),
+		attribute.String("coalesced_scope", scope),
+	)
+	c.coalesced.Add(ctx, 1, metric.WithAttributes(attribute.String("scope", scope)))
+}
+
+// coalesce fetches a missing key once for all the concurrent callers of this
+// instance. With a Config.Locker, an instance that finds another one
+// fetching the key waits for it to cache the value, looking it up with
+// cached, and only fetches it itself if it doesn't show up in time. The
+// shared fetch goes on when the caller that started it gives up.
+func coalesce[T any](ctx context.Context, s SpotifySearchService, key string, cached func(ctx context.Context) (T, error), fetch func(ctx context.Context) (T, error)) (T, error) {
+	started := false
+	results := s.coalescer.group.DoChan(key, func() (any, error) {
+		started = true
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+
+		return lockedFetch(ctx, s, key, cached, fetch)
+	})
+
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case result := <-results:
+		if !started {
+			s.coalescer.record(ctx, coalescedLocal)
+		}
+		value, _ := result.Val.(T)
+		return value, result.Err
+	}
+}
+
+// lockedFetch fetches the key while holding its lock, or waits for the
+// instance holding it to cache the value.
+func lockedFetch[T any](ctx context.Context, s SpotifySearchService, key string, cached func(ctx context.Context) (T, error), fetch func(ctx context.Context) (T, error)) (T, error) {
+	if s.config.Locker == nil {
+		return fetch(ctx)
+	}
+
+	unlock, acquired, err := s.config.Locker.TryLock(ctx, "lock:"+key, lockTTL)
+	if err != nil {
+		// Fetching twice is better than not at all
+		trace.SpanFromContext(ctx).RecordError(err)
+		return fetch(ctx)
+	}
+	if acquired {
+		defer func() {
+			if err := unlock(ctx); err != nil {
+				trace.SpanFromContext(ctx).RecordError(err)
+			}
+		}()
+		return fetch(ctx)
+	}
+
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+	timeout := time.NewTimer(lockWait)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-timeout.C:
+			return fetch(ctx)
+		case <-ticker.C:
+			value, err := cached(ctx)
+			if errors.Is(err, errNotCached) {
+				continue
+			}
+			s.coalescer.record(ctx, coalescedDistributed)
+			return value, err
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package spotify_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// coalescedRequests returns the number of coalesced requests recorded by the
+// reader, per scope.
+func coalescedRequests(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	var metrics metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &metrics))
+
+	counts := map[string]int64{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "spotify.coalesced_requests" {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				value, _ := point.Attributes.Value(attribute.Key("scope"))
+				counts[value.AsString()] += point.Value
+			}
+		}
+	}
+	return counts
+}
+
+func TestSpotifySearchService_Coalescing(t *testing.T) {
+	opts := spotify.SearchOptions{Limit: 10}
+	key := "spotify:v2:g0:artist:::10:0:twice"
+
+	newService := func(t *testing.T, locker spotify.Locker) (spotify.SpotifySearchService, *mocks.MockSpotifyClient, *mocks.MockCache, sdkmetric.Reader) {
+		mockedSpotifyClient := &mocks.MockSpotifyClient{}
+		mockedCache := &mocks.MockCache{}
+		t.Cleanup(func() {
+			mockedCache.AssertExpectations(t)
+			mockedSpotifyClient.AssertExpectations(t)
+		})
+
+		reader := sdkmetric.NewManualReader()
+
+		s := spotify.New(
+			otel.Tracer("test"),
+			mockedSpotifyClient,
+			mockedCache,
+			spotify.Config{
+				Locker: locker,
+				Meter:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"),
+			},
+		)
+		return s, mockedSpotifyClient, mockedCache, reader
+	}
+
+	results := map[string]*spotify.SearchPage{
+		"artist": spotify.NewSearchPage([]domain.Item{newItem(t, "artist", "TWICE")}, 1, opts),
+	}
+
+	t.Run("concurrent misses", func(t *testing.T) {
+		s, mockedSpotifyClient, mockedCache, reader := newService(t, nil)
+
+		searching := make(chan struct{})
+		release := make(chan struct{})
+		missed := make(chan struct{}, 2)
+
+		mockedCache.On("Get", mock.Anything, key).
+			Return("", spotify.ErrCacheMiss).
+			Run(func(mock.Arguments) { missed <- struct{}{} }).
+			Twice()
+		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"artist"}, opts).
+			Return(results, nil).
+			Run(func(mock.Arguments) {
+				close(searching)
+				<-release
+			}).
+			Once()
+		mockedCache.On("Set", mock.Anything, key, mock.Anything, spotify.DefaultSearchTTL).
+			Return(nil).
+			Once()
+
+		var wg sync.WaitGroup
+		search := func() {
+			defer wg.Done()
+			page, err := s.SearchPage(context.Background(), "TWICE", "artist", opts)
+			assert.NoError(t, err)
+			assert.Equal(t, "TWICE", page.Items[0].ObjectName())
+		}
+
+		wg.Add(2)
+		go search()
+		<-missed
+		<-searching
+		go search()
+		<-missed
+		// Let the second search join the first one's upstream call
+		time.Sleep(time.Millisecond * 50)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, map[string]int64{"local": 1}, coalescedRequests(t, reader))
+	})
+
+	t.Run("lock acquired", func(t *testing.T) {
+		mockedLocker := &mocks.MockLocker{}
+		t.Cleanup(func() { mockedLocker.AssertExpectations(t) })
+
+		s, mockedSpotifyClient, mockedCache, reader := newService(t, mockedLocker)
+
+		unlocked := false
+		mockedCache.On("Get", mock.Anything, key).
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedLocker.On("TryLock", mock.Anything, "lock:"+key, mock.Anything).
+			Return(func(context.Context) error {
+				unlocked = true
+				return nil
+			}, true, nil).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"artist"}, opts).
+			Return(results, nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, key, mock.Anything, spotify.DefaultSearchTTL).
+			Return(nil).
+			Once()
+
+		page, err := s.SearchPage(context.Background(), "TWICE", "artist", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "TWICE", page.Items[0].ObjectName())
+		assert.True(t, unlocked)
+		assert.Empty(t, coalescedRequests(t, reader))
+	})
+
+	t.Run("locked by another instance", func(t *testing.T) {
+		mockedLocker := &mocks.MockLocker{}
+		t.Cleanup(func() { mockedLocker.AssertExpectations(t) })
+
+		s, _, mockedCache, reader := newService(t, mockedLocker)
+
+		mockedCache.On("Get", mock.Anything, key).
+			Return("", spotify.ErrCacheMiss).
+			Twice()
+		mockedLocker.On("TryLock", mock.Anything, "lock:"+key, mock.Anything).
+			Return(nil, false, nil).
+			Once()
+		mockedCache.On("Get", mock.Anything, key).
+			Return(`{"items": [{"type": "artist", "name": "cached"}], "total": 1}`, nil).
+			Once()
+
+		page, err := s.SearchPage(context.Background(), "TWICE", "artist", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "cached", page.Items[0].ObjectName())
+		assert.Equal(t, map[string]int64{"distributed": 1}, coalescedRequests(t, reader))
+	})
+}
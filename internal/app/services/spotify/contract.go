@@ -2,14 +2,54 @@ package spotify
 
 import (
 	"context"
+	"errors"
 	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
 )
 
+// ErrCacheMiss is returned by Cache.Get for missing or expired keys, by all
+// the cache backends.
+var ErrCacheMiss = errors.New("cache: key not found")
+
 type Cache interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// MGet returns the values of the keys in the same order, with an empty
+	// string for missing keys.
+	MGet(ctx context.Context, keys []string) ([]string, error)
+	MSet(ctx context.Context, values map[string][]byte, ttl time.Duration) error
 }
 
 type SpotifyClient interface {
-	Search(ctx context.Context, query string, searchType string) (any, error)
+	Search(ctx context.Context, query string, searchTypes []string, opts SearchOptions) (map[string]*SearchPage, error)
+	// GetArtists, GetAlbums and GetTracks return the items in the same order
+	// as the IDs, with nil for unknown IDs.
+	GetArtists(ctx context.Context, ids []string) ([]domain.Item, error)
+	GetAlbums(ctx context.Context, ids []string, market string) ([]domain.Item, error)
+	GetTracks(ctx context.Context, ids []string, market string) ([]domain.Item, error)
+	GetArtistTopTracks(ctx context.Context, id string, market string) ([]domain.Item, error)
+	GetArtistAlbums(ctx context.Context, id string, includeGroups []string, opts SearchOptions) (*SearchPage, error)
+	GetRelatedArtists(ctx context.Context, id string) ([]domain.Item, error)
+	GetAlbumTracks(ctx context.Context, id string, market string, maxTracks int) (*Tracklist, error)
+}
+
+// ShortLinkResolver returns the URL a short link redirects to. Unknown short
+// links are reported with ErrInvalidLink.
+type ShortLinkResolver interface {
+	Resolve(ctx context.Context, link string) (string, error)
+}
+
+// Sweeper deletes the cache keys matching a SCAN-style pattern for which
+// match returns true, and returns the number of deleted keys.
+type Sweeper interface {
+	DeleteMatching(ctx context.Context, pattern string, match func(key string) bool) (int, error)
+}
+
+// Locker is a lock shared by the instances of the proxy, so that only one of
+// them fetches a missing key at a time.
+type Locker interface {
+	// TryLock acquires the lock of the key for at most ttl, without waiting.
+	// The returned function releases it when acquired.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(ctx context.Context) error, acquired bool, err error)
 }
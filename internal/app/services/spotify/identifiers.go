@@ -0,0 +1,155 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Preferences between the explicit and clean versions of a track.
+const (
+	PreferExplicit = "explicit"
+	PreferClean    = "clean"
+)
+
+// LookupISRC returns the tracks with the given ISRC, such as the same
+// recording released on the original album, as a single and on compilations.
+// They are ranked from the most to the least likely to be the original
+// release, optionally preferring the explicit or clean version.
+func (s SpotifySearchService) LookupISRC(ctx context.Context, isrc string, market string, prefer string) ([]domain.Item, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.LookupISRC")
+	defer span.End()
+
+	// ISRCs are often written with hyphens, like US-RC1-76-07839
+	isrc = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(isrc)))
+	if !isrcPattern.MatchString(isrc) {
+		return nil, fmt.Errorf("%w: invalid isrc %q", ErrInvalidID, isrc)
+	}
+
+	span.SetAttributes(attribute.String("isrc", isrc))
+
+	return s.lookupIdentifier(ctx, "track", SearchFilters{ISRC: isrc}, market, prefer)
+}
+
+// LookupUPC returns the albums with the given UPC or EAN, ranked like
+// LookupISRC. Albums can't be explicit, so prefer only applies to tracks.
+func (s SpotifySearchService) LookupUPC(ctx context.Context, upc string, market string, prefer string) ([]domain.Item, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.LookupUPC")
+	defer span.End()
+
+	upc = strings.TrimSpace(upc)
+	if !upcPattern.MatchString(upc) || !validCheckDigit(upc) {
+		return nil, fmt.Errorf("%w: invalid upc %q", ErrInvalidID, upc)
+	}
+
+	span.SetAttributes(attribute.String("upc", upc))
+
+	return s.lookupIdentifier(ctx, "album", SearchFilters{UPC: upc}, market, prefer)
+}
+
+// lookupIdentifier searches for the items matching the filters. Results are
+// cached before ranking, as the ranking depends on the preference.
+func (s SpotifySearchService) lookupIdentifier(ctx context.Context, searchType string, filters SearchFilters, market string, prefer string) ([]domain.Item, error) {
+	if prefer != "" && prefer != PreferExplicit && prefer != PreferClean {
+		return nil, fmt.Errorf("%w: prefer must be %s or %s", ErrInvalidFilter, PreferExplicit, PreferClean)
+	}
+
+	market, err := s.market(market)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := buildQuery("", filters, []string{searchType}, s.normalization(searchType))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := SearchOptions{Limit: MaxSearchLimit, Market: market}
+
+	key := s.keyspace.key(fmt.Sprintf("identifier:%s:%s:%s", searchType, market, query.key))
+	items, err := cached(ctx, s, key, s.resourceTTL("identifier"), func(ctx context.Context) (domain.Items, error) {
+		results, err := s.spotifyClient.Search(ctx, query.text, []string{searchType}, opts)
+		if err != nil {
+			return nil, err
+		}
+		// Cached for a shorter while, as the identifier may just not be
+		// released yet
+		if results[searchType] == nil || len(results[searchType].Items) == 0 {
+			return nil, ErrNoResultsFound
+		}
+		return results[searchType].Items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rankReleases(items, prefer), nil
+}
+
+// rankReleases sorts releases from the most to the least likely to be the
+// original one: compilations last, then the preferred version first, then the
+// earliest release and the most popular one.
+func rankReleases(items []domain.Item, prefer string) []domain.Item {
+	ranked := append([]domain.Item(nil), items...)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := releaseOf(ranked[i], prefer), releaseOf(ranked[j], prefer)
+		if a.compilation != b.compilation {
+			return !a.compilation
+		}
+		if a.preferred != b.preferred {
+			return a.preferred
+		}
+		if a.releaseDate != b.releaseDate {
+			// Unknown release dates go last
+			return a.releaseDate != "" && (b.releaseDate == "" || a.releaseDate < b.releaseDate)
+		}
+		return a.popularity > b.popularity
+	})
+
+	return ranked
+}
+
+type release struct {
+	compilation bool
+	preferred   bool
+	releaseDate string
+	popularity  int
+}
+
+func releaseOf(item domain.Item, prefer string) release {
+	switch item := item.(type) {
+	case *domain.Track:
+		return release{
+			compilation: item.Album.AlbumType == "compilation",
+			preferred:   (prefer == PreferExplicit && item.Explicit) || (prefer == PreferClean && !item.Explicit),
+			releaseDate: item.Album.ReleaseDate,
+			popularity:  item.Popularity,
+		}
+	case *domain.Album:
+		return release{
+			compilation: item.AlbumType == "compilation",
+			releaseDate: item.ReleaseDate,
+			popularity:  item.Popularity,
+		}
+	}
+	return release{}
+}
+
+// validCheckDigit checks the last digit of a UPC-A or an EAN-13 barcode.
+func validCheckDigit(code string) bool {
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := int(code[i] - '0')
+		// Weights alternate 3, 1, 3... starting from the rightmost data digit
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
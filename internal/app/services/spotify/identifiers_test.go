@@ -0,0 +1,198 @@
+package spotify_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func newRelease(t *testing.T, id string, albumType string, releaseDate string, explicit bool) domain.Item {
+	item, err := domain.UnmarshalItem([]byte(fmt.Sprintf(
+		`{"type": "track", "id": %q, "name": "Bohemian Rhapsody", "explicit": %t, "album": {"album_type": %q, "release_date": %q}}`,
+		id, explicit, albumType, releaseDate,
+	)))
+	require.NoError(t, err)
+	return item
+}
+
+func TestSpotifySearchService_LookupISRC(t *testing.T) {
+	original := newRelease(t, "original", "album", "1975-10-31", false)
+	single := newRelease(t, "single", "single", "1975-10-31", true)
+	remaster := newRelease(t, "remaster", "album", "2011-01-01", false)
+	compilation := newRelease(t, "compilation", "compilation", "1981-10-26", false)
+
+	tests := []struct {
+		name        string
+		isrc        string
+		prefer      string
+		setup       func(client *mocks.MockSpotifyClient, cache *mocks.MockCache)
+		expectedIDs []string
+		expectedErr error
+	}{
+		{
+			name: "original first",
+			isrc: "GB-UM7-10-50001",
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				key := "spotify:v2:g0:identifier:track:US:isrc:GBUM71050001"
+				cache.On("Get", mock.Anything, key).
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				client.On("Search", mock.Anything, "isrc:GBUM71050001", []string{"track"}, spotify.SearchOptions{Limit: 50, Market: "US"}).
+					Return(map[string]*spotify.SearchPage{
+						"track": {Items: []domain.Item{compilation, remaster, single, original}},
+					}, nil).
+					Once()
+				cache.On("Set", mock.Anything, key, mock.Anything, time.Hour*24*30).
+					Return(nil).
+					Once()
+			},
+			expectedIDs: []string{"single", "original", "remaster", "compilation"},
+		},
+		{
+			name:   "clean version first",
+			isrc:   "GBUM71050001",
+			prefer: "clean",
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("Get", mock.Anything, "spotify:v2:g0:identifier:track:US:isrc:GBUM71050001").
+					Return(`[
+						{"type": "track", "id": "single", "explicit": true, "album": {"album_type": "single", "release_date": "1975-10-31"}},
+						{"type": "track", "id": "original", "album": {"album_type": "album", "release_date": "1975-10-31"}}
+					]`, nil).
+					Once()
+			},
+			expectedIDs: []string{"original", "single"},
+		},
+		{
+			name: "no results",
+			isrc: "GBUM71050001",
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("Get", mock.Anything, mock.Anything).
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				client.On("Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(map[string]*spotify.SearchPage{"track": {}}, nil).
+					Once()
+				cache.On("Set", mock.Anything, mock.Anything, []byte(domain.NotFoundCacheValue), spotify.DefaultNegativeCacheTTL).
+					Return(nil).
+					Once()
+			},
+			expectedErr: spotify.ErrNoResultsFound,
+		},
+		{
+			name: "cached no results",
+			isrc: "GBUM71050001",
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("Get", mock.Anything, mock.Anything).
+					Return(domain.NotFoundCacheValue, nil).
+					Once()
+			},
+			expectedErr: spotify.ErrNoResultsFound,
+		},
+		{
+			name: "client error",
+			isrc: "GBUM71050001",
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("Get", mock.Anything, mock.Anything).
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				client.On("Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("timeout")).
+					Once()
+			},
+			expectedErr: spotify.ErrSpotifyClient,
+		},
+		{
+			name:        "invalid isrc",
+			isrc:        "GBUM7105",
+			expectedErr: spotify.ErrInvalidID,
+		},
+		{
+			name:        "invalid preference",
+			isrc:        "GBUM71050001",
+			prefer:      "radio",
+			expectedErr: spotify.ErrInvalidFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedSpotifyClient := &mocks.MockSpotifyClient{}
+			mockedCache := &mocks.MockCache{}
+			t.Cleanup(func() {
+				mockedCache.AssertExpectations(t)
+				mockedSpotifyClient.AssertExpectations(t)
+			})
+
+			s := spotify.New(otel.Tracer("test"), mockedSpotifyClient, mockedCache, spotify.Config{DefaultMarket: "US"})
+
+			if tt.setup != nil {
+				tt.setup(mockedSpotifyClient, mockedCache)
+			}
+
+			items, err := s.LookupISRC(context.Background(), tt.isrc, "", tt.prefer)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var ids []string
+			for _, item := range items {
+				ids = append(ids, item.ObjectID())
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestSpotifySearchService_LookupUPC(t *testing.T) {
+	tests := []struct {
+		name  string
+		upc   string
+		valid bool
+	}{
+		{name: "upc", upc: "036000291452", valid: true},
+		{name: "ean", upc: "4006381333931", valid: true},
+		{name: "wrong check digit", upc: "036000291453"},
+		{name: "too short", upc: "03600029145"},
+		{name: "letters", upc: "03600029145A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedSpotifyClient := &mocks.MockSpotifyClient{}
+			mockedCache := &mocks.MockCache{}
+			t.Cleanup(func() {
+				mockedCache.AssertExpectations(t)
+				mockedSpotifyClient.AssertExpectations(t)
+			})
+
+			s := spotify.New(otel.Tracer("test"), mockedSpotifyClient, mockedCache, spotify.Config{})
+
+			if !tt.valid {
+				_, err := s.LookupUPC(context.Background(), tt.upc, "", "")
+				assert.ErrorIs(t, err, spotify.ErrInvalidID)
+				return
+			}
+
+			mockedCache.On("Get", mock.Anything, "spotify:v2:g0:identifier:album::upc:"+tt.upc).
+				Return(`[{"type": "album", "id": "`+fancyID+`", "album_type": "album"}]`, nil).
+				Once()
+
+			items, err := s.LookupUPC(context.Background(), tt.upc, "", "")
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, fancyID, items[0].ObjectID())
+		})
+	}
+}
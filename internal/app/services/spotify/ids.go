@@ -0,0 +1,233 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// IDTypes are the types that can be fetched by ID.
+var IDTypes = []string{"artist", "album", "track"}
+
+// MaxIDs is the maximum number of IDs that can be fetched at once. The client
+// splits them into as many calls as Spotify's per-call maximums require.
+const MaxIDs = 100
+
+var idPattern = regexp.MustCompile(`^[0-9A-Za-z]{22}$`)
+
+// idCacheTTLs are the default cache TTLs of the items fetched by ID. Artists
+// change more often than albums and tracks, as they include followers and
+// the popularity of their latest releases.
+var idCacheTTLs = map[string]time.Duration{
+	"artist": time.Hour * 24,
+	"album":  time.Hour * 24 * 7,
+	"track":  time.Hour * 24 * 7,
+}
+
+// GetByID returns the artist, album or track with the given Spotify ID.
+func (s SpotifySearchService) GetByID(ctx context.Context, itemType string, id string, market string) (domain.Item, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.GetByID")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("type", itemType),
+		attribute.String("id", id),
+	)
+
+	items, err := s.getByIDs(ctx, itemType, []string{id}, market)
+	if err != nil {
+		return nil, err
+	}
+
+	if items[0] == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotFound, itemType, id)
+	}
+
+	return items[0], nil
+}
+
+// GetByIDs returns the artists, albums or tracks with the given Spotify IDs,
+// in the same order, with nil for unknown IDs.
+func (s SpotifySearchService) GetByIDs(ctx context.Context, itemType string, ids []string, market string) ([]domain.Item, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.GetByIDs")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("type", itemType),
+		attribute.Int("ids", len(ids)),
+	)
+
+	if len(ids) == 0 || len(ids) > MaxIDs {
+		return nil, fmt.Errorf("%w: between 1 and %d ids are required", ErrInvalidID, MaxIDs)
+	}
+
+	return s.getByIDs(ctx, itemType, ids, market)
+}
+
+func (s SpotifySearchService) getByIDs(ctx context.Context, itemType string, ids []string, market string) ([]domain.Item, error) {
+	if !slices.Contains(IDTypes, itemType) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidQueryType, itemType)
+	}
+
+	for _, id := range ids {
+		if !idPattern.MatchString(id) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidID, id)
+		}
+	}
+
+	// Artists are the same in every market
+	market, err := s.market(market)
+	if err != nil {
+		return nil, err
+	}
+	if itemType == "artist" {
+		market = ""
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("market", market))
+
+	var uniqueIDs []string
+	for _, id := range ids {
+		if !slices.Contains(uniqueIDs, id) {
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+
+	keys := make([]string, 0, len(uniqueIDs))
+	for _, id := range uniqueIDs {
+		keys = append(keys, s.idCacheKey(itemType, market, id))
+	}
+
+	found := make(map[string]domain.Item, len(uniqueIDs))
+	var staleIDs, staleKeys []string
+
+	values, err := s.cache.MGet(ctx, keys)
+	if err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		payload, stale := decodeEntry(value)
+		if item, err := domain.UnmarshalItem(payload); err == nil {
+			found[uniqueIDs[i]] = item
+			if stale {
+				staleIDs = append(staleIDs, uniqueIDs[i])
+				staleKeys = append(staleKeys, keys[i])
+			}
+		}
+	}
+
+	if len(staleIDs) > 0 {
+		s.serveStale(ctx, strings.Join(staleKeys, ","), func(ctx context.Context) error {
+			fetched, err := s.fetchByIDs(ctx, itemType, staleIDs, market)
+			if err != nil {
+				return err
+			}
+			return s.cacheItems(ctx, itemType, market, staleIDs, fetched)
+		})
+	}
+
+	var missingIDs []string
+	for _, id := range uniqueIDs {
+		if _, cached := found[id]; !cached {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("cache_misses", len(missingIDs)))
+
+	if len(missingIDs) > 0 {
+		fetched, err := s.fetchByIDs(ctx, itemType, missingIDs, market)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrSpotifyClient, err.Error())
+		}
+
+		for i, item := range fetched {
+			if item != nil {
+				found[missingIDs[i]] = item
+			}
+		}
+
+		if err := s.cacheItems(ctx, itemType, market, missingIDs, fetched); err != nil {
+			trace.SpanFromContext(ctx).RecordError(err)
+		}
+	}
+
+	items := make([]domain.Item, len(ids))
+	for i, id := range ids {
+		items[i] = found[id]
+	}
+
+	return items, nil
+}
+
+// cacheItems caches the items fetched for the IDs, skipping the unknown
+// ones.
+func (s SpotifySearchService) cacheItems(ctx context.Context, itemType string, market string, ids []string, items []domain.Item) error {
+	toCache := map[string][]byte{}
+	for i, item := range items {
+		if item == nil {
+			continue
+		}
+
+		marshaledItem, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		toCache[s.idCacheKey(itemType, market, ids[i])] = marshaledItem
+	}
+
+	if len(toCache) == 0 {
+		return nil
+	}
+	return s.msetJittered(ctx, toCache, s.idTTL(itemType), true)
+}
+
+func (s SpotifySearchService) fetchByIDs(ctx context.Context, itemType string, ids []string, market string) ([]domain.Item, error) {
+	var items []domain.Item
+	var err error
+
+	switch itemType {
+	case "artist":
+		items, err = s.spotifyClient.GetArtists(ctx, ids)
+	case "album":
+		items, err = s.spotifyClient.GetAlbums(ctx, ids, market)
+	case "track":
+		items, err = s.spotifyClient.GetTracks(ctx, ids, market)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) != len(ids) {
+		return nil, fmt.Errorf("got %d items for %d ids", len(items), len(ids))
+	}
+	return items, nil
+}
+
+// market returns the given market or the default one, and validates it.
+func (s SpotifySearchService) market(market string) (string, error) {
+	if market == "" {
+		market = s.config.DefaultMarket
+	}
+	market = strings.ToUpper(market)
+
+	if market != "" && !marketPattern.MatchString(market) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidMarket, market)
+	}
+	return market, nil
+}
+
+func (s SpotifySearchService) idCacheKey(itemType string, market string, id string) string {
+	return s.keyspace.key(fmt.Sprintf("id:%s:%s:%s", itemType, market, id))
+}
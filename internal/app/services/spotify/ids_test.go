@@ -0,0 +1,167 @@
+package spotify_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	twiceID = "7n2Ycct7Beij7Dj7meI4X0"
+	ivesID  = "6RHTUrRF63xao58xh9FXYJ"
+	fancyID = "2pWnuwM6fPpsk9kTPxkDpM"
+)
+
+func newItemWithID(t *testing.T, itemType string, id string, name string) domain.Item {
+	item, err := domain.UnmarshalItem([]byte(fmt.Sprintf(`{"type": %q, "id": %q, "name": %q}`, itemType, id, name)))
+	require.NoError(t, err)
+	return item
+}
+
+func TestSpotifySearchService_GetByID(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+
+	s := spotify.New(
+		otel.Tracer("test"),
+		mockedSpotifyClient,
+		mockedCache,
+		spotify.Config{DefaultMarket: "US"},
+	)
+
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := s.GetByID(context.Background(), "playlist", twiceID, "")
+		assert.ErrorIs(t, err, spotify.ErrInvalidQueryType)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := s.GetByID(context.Background(), "artist", "twice", "")
+		assert.ErrorIs(t, err, spotify.ErrInvalidID)
+	})
+
+	t.Run("invalid market", func(t *testing.T) {
+		_, err := s.GetByID(context.Background(), "track", fancyID, "USA")
+		assert.ErrorIs(t, err, spotify.ErrInvalidMarket)
+	})
+
+	t.Run("cache hit", func(t *testing.T) {
+		mockedCache.On("MGet", mock.Anything, []string{"spotify:v2:g0:id:artist::" + twiceID}).
+			Return([]string{`{"type": "artist", "id": "` + twiceID + `", "name": "TWICE"}`}, nil).
+			Once()
+
+		item, err := s.GetByID(context.Background(), "artist", twiceID, "KR")
+		require.NoError(t, err)
+		assert.Equal(t, "TWICE", item.ObjectName())
+	})
+
+	t.Run("cache miss", func(t *testing.T) {
+		key := "spotify:v2:g0:id:track:US:" + fancyID
+
+		mockedCache.On("MGet", mock.Anything, []string{key}).
+			Return([]string{""}, nil).
+			Once()
+		mockedSpotifyClient.On("GetTracks", mock.Anything, []string{fancyID}, "US").
+			Return([]domain.Item{newItemWithID(t, "track", fancyID, "FANCY")}, nil).
+			Once()
+		mockedCache.On("MSet", mock.Anything, mock.MatchedBy(func(values map[string][]byte) bool {
+			_, ok := values[key]
+			return ok && len(values) == 1
+		}), time.Hour*24*7).
+			Return(nil).
+			Once()
+
+		item, err := s.GetByID(context.Background(), "track", fancyID, "")
+		require.NoError(t, err)
+		assert.Equal(t, "FANCY", item.ObjectName())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockedCache.On("MGet", mock.Anything, []string{"spotify:v2:g0:id:album:US:" + fancyID}).
+			Return([]string{""}, nil).
+			Once()
+		mockedSpotifyClient.On("GetAlbums", mock.Anything, []string{fancyID}, "US").
+			Return([]domain.Item{nil}, nil).
+			Once()
+
+		_, err := s.GetByID(context.Background(), "album", fancyID, "")
+		assert.ErrorIs(t, err, spotify.ErrNotFound)
+	})
+
+	t.Run("spotify client error", func(t *testing.T) {
+		mockedCache.On("MGet", mock.Anything, []string{"spotify:v2:g0:id:artist::" + ivesID}).
+			Return(nil, errors.New("connection refused")).
+			Once()
+		mockedSpotifyClient.On("GetArtists", mock.Anything, []string{ivesID}).
+			Return(nil, errors.New("rate limited")).
+			Once()
+
+		_, err := s.GetByID(context.Background(), "artist", ivesID, "")
+		assert.ErrorIs(t, err, spotify.ErrSpotifyClient)
+	})
+
+	mockedCache.AssertExpectations(t)
+	mockedSpotifyClient.AssertExpectations(t)
+}
+
+func TestSpotifySearchService_GetByIDs(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+
+	s := spotify.New(
+		otel.Tracer("test"),
+		mockedSpotifyClient,
+		mockedCache,
+		spotify.Config{},
+	)
+
+	t.Run("too many ids", func(t *testing.T) {
+		ids := make([]string, spotify.MaxIDs+1)
+		for i := range ids {
+			ids[i] = twiceID
+		}
+
+		_, err := s.GetByIDs(context.Background(), "artist", ids, "")
+		assert.ErrorIs(t, err, spotify.ErrInvalidID)
+	})
+
+	t.Run("same order as the ids", func(t *testing.T) {
+		mockedCache.On("MGet", mock.Anything, []string{
+			"spotify:v2:g0:id:artist::" + ivesID,
+			"spotify:v2:g0:id:artist::" + twiceID,
+			"spotify:v2:g0:id:artist::" + fancyID,
+		}).
+			Return([]string{"", `{"type": "artist", "id": "` + twiceID + `", "name": "TWICE"}`, ""}, nil).
+			Once()
+		mockedSpotifyClient.On("GetArtists", mock.Anything, []string{ivesID, fancyID}).
+			Return([]domain.Item{newItemWithID(t, "artist", ivesID, "IVE"), nil}, nil).
+			Once()
+		mockedCache.On("MSet", mock.Anything, mock.MatchedBy(func(values map[string][]byte) bool {
+			_, ok := values["spotify:v2:g0:id:artist::"+ivesID]
+			return ok && len(values) == 1
+		}), time.Hour*24).
+			Return(nil).
+			Once()
+
+		items, err := s.GetByIDs(context.Background(), "artist", []string{ivesID, twiceID, fancyID, ivesID}, "")
+		require.NoError(t, err)
+		require.Len(t, items, 4)
+
+		assert.Equal(t, "IVE", items[0].ObjectName())
+		assert.Equal(t, "TWICE", items[1].ObjectName())
+		assert.Nil(t, items[2])
+		assert.Equal(t, "IVE", items[3].ObjectName())
+	})
+
+	mockedCache.AssertExpectations(t)
+	mockedSpotifyClient.AssertExpectations(t)
+}
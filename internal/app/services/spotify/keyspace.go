@@ -0,0 +1,198 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultNamespace prefixes the cache keys when Config.Namespace is empty.
+const DefaultNamespace = "spotify"
+
+// generationRefreshInterval is how long instances may keep using the
+// previous generation after another one bumped it.
+const generationRefreshInterval = time.Second * 10
+
+// keyspace holds the current generation of the cache keys. All the keys of
+// the service are prefixed with the namespace, domain.SchemaVersion and the
+// generation, so that changing the JSON shape of the results or bumping the
+// generation invalidates them at once.
+type keyspace struct {
+	namespace  string
+	generation atomic.Int64
+}
+
+func newKeyspace(namespace string) *keyspace {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	return &keyspace{namespace: namespace}
+}
+
+// key prefixes a cache key with the namespace, schema version and current
+// generation.
+func (k *keyspace) key(key string) string {
+	return fmt.Sprintf("%s:v%d:g%d:%s", k.namespace, domain.SchemaVersion, k.generation.Load(), key)
+}
+
+// generationKey stores the current generation, shared by the instances.
+func (k *keyspace) generationKey() string {
+	return k.namespace + ":generation"
+}
+
+// old tells whether a key of the namespace belongs to a previous schema
+// version or generation, or predates them. Keys of newer schema versions
+// are kept, as they are used by instances being deployed.
+func (k *keyspace) old(key string) bool {
+	if key == k.generationKey() {
+		return false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, k.namespace+":"), ":", 3)
+	if len(parts) < 3 || !strings.HasPrefix(parts[0], "v") || !strings.HasPrefix(parts[1], "g") {
+		return true
+	}
+
+	version, err := strconv.Atoi(parts[0][1:])
+	if err != nil {
+		return true
+	}
+	generation, err := strconv.ParseInt(parts[1][1:], 10, 64)
+	if err != nil {
+		return true
+	}
+
+	if version != domain.SchemaVersion {
+		return version < domain.SchemaVersion
+	}
+	return generation < k.generation.Load()
+}
+
+// Generation returns the generation of the cache keys.
+func (s SpotifySearchService) Generation() int64 {
+	return s.keyspace.generation.Load()
+}
+
+// RefreshGeneration reads the generation of the cache keys, which other
+// instances may have bumped.
+func (s SpotifySearchService) RefreshGeneration(ctx context.Context) error {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.RefreshGeneration")
+	defer span.End()
+
+	generation, err := s.storedGeneration(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	// The generation may be missing from the cache, evicted or flushed, and
+	// must not go back to one whose keys might still be cached
+	generation = max(generation, s.keyspace.generation.Load())
+
+	span.SetAttributes(attribute.Int64("generation", generation))
+	s.keyspace.generation.Store(generation)
+	return nil
+}
+
+// BumpGeneration invalidates all the cached results at once, by moving to
+// the next generation of cache keys. Other instances follow within
+// generationRefreshInterval, and the keys of the previous generations
+// expire or get deleted by DeleteOldGenerations.
+func (s SpotifySearchService) BumpGeneration(ctx context.Context) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.BumpGeneration")
+	defer span.End()
+
+	generation, err := s.storedGeneration(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+	generation = max(generation, s.keyspace.generation.Load()) + 1
+
+	err = s.cache.Set(ctx, s.keyspace.generationKey(), []byte(strconv.FormatInt(generation, 10)), 0)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("cache set generation: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("generation", generation))
+	s.keyspace.generation.Store(generation)
+	return generation, nil
+}
+
+// WatchGeneration refreshes the generation of the cache keys until the
+// context is done.
+func (s SpotifySearchService) WatchGeneration(ctx context.Context) {
+	ticker := time.NewTicker(generationRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors are recorded on the span, and the next refresh retries
+			_ = s.RefreshGeneration(ctx)
+		}
+	}
+}
+
+// DeleteOldGenerations deletes the cache keys of the previous schema
+// versions and generations, rather than waiting for them to expire. It is a
+// no-op without a Config.Sweeper.
+func (s SpotifySearchService) DeleteOldGenerations(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.DeleteOldGenerations")
+	defer span.End()
+
+	if s.config.Sweeper == nil {
+		return 0, nil
+	}
+
+	deleted, err := s.config.Sweeper.DeleteMatching(ctx, s.keyspace.namespace+":*", s.keyspace.old)
+	span.SetAttributes(attribute.Int("deleted", deleted))
+	if err != nil {
+		span.RecordError(err)
+		return deleted, fmt.Errorf("delete old generations: %w", err)
+	}
+	return deleted, nil
+}
+
+// RunJanitor deletes the cache keys of the previous schema versions and
+// generations every interval, until the context is done.
+func (s SpotifySearchService) RunJanitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors are recorded on the span, and the next run retries
+			_, _ = s.DeleteOldGenerations(ctx)
+		}
+	}
+}
+
+func (s SpotifySearchService) storedGeneration(ctx context.Context) (int64, error) {
+	value, err := s.cache.Get(ctx, s.keyspace.generationKey())
+	if err != nil {
+		// Either the generation was never bumped, or the cache is down and
+		// the current one is as good as any
+		trace.SpanFromContext(ctx).RecordError(err)
+		return s.keyspace.generation.Load(), nil
+	}
+
+	generation, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache generation %q: %w", value, err)
+	}
+	return generation, nil
+}
@@ -0,0 +1,174 @@
+package spotify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifySearchService_BumpGeneration(t *testing.T) {
+	opts := spotify.SearchOptions{Limit: 10}
+
+	t.Run("bumped", func(t *testing.T) {
+		mockedCache := &mocks.MockCache{}
+		defer mockedCache.AssertExpectations(t)
+
+		s := spotify.New(otel.Tracer("test"), &mocks.MockSpotifyClient{}, mockedCache, spotify.Config{})
+
+		mockedCache.On("Get", mock.Anything, "spotify:generation").
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedCache.On("Set", mock.Anything, "spotify:generation", []byte("1"), mock.Anything).
+			Return(nil).
+			Once()
+
+		generation, err := s.BumpGeneration(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), generation)
+		assert.Equal(t, int64(1), s.Generation())
+
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g1:artist:::10:0:twice").
+			Return(`{"items": [{"type": "artist", "name": "TWICE"}], "total": 1}`, nil).
+			Once()
+
+		page, err := s.SearchPage(context.Background(), "TWICE", "artist", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "TWICE", page.Items[0].ObjectName())
+	})
+
+	t.Run("bumped by another instance", func(t *testing.T) {
+		mockedCache := &mocks.MockCache{}
+		defer mockedCache.AssertExpectations(t)
+
+		s := spotify.New(otel.Tracer("test"), &mocks.MockSpotifyClient{}, mockedCache, spotify.Config{Namespace: "proxy"})
+
+		mockedCache.On("Get", mock.Anything, "proxy:generation").
+			Return("4", nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, "proxy:generation", []byte("5"), mock.Anything).
+			Return(nil).
+			Once()
+
+		generation, err := s.BumpGeneration(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), generation)
+	})
+
+	t.Run("cache error", func(t *testing.T) {
+		mockedCache := &mocks.MockCache{}
+		defer mockedCache.AssertExpectations(t)
+
+		s := spotify.New(otel.Tracer("test"), &mocks.MockSpotifyClient{}, mockedCache, spotify.Config{})
+
+		mockedCache.On("Get", mock.Anything, "spotify:generation").
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedCache.On("Set", mock.Anything, "spotify:generation", []byte("1"), mock.Anything).
+			Return(errors.New("connection refused")).
+			Once()
+
+		_, err := s.BumpGeneration(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), s.Generation())
+	})
+}
+
+func TestSpotifySearchService_RefreshGeneration(t *testing.T) {
+	mockedCache := &mocks.MockCache{}
+	defer mockedCache.AssertExpectations(t)
+
+	s := spotify.New(otel.Tracer("test"), &mocks.MockSpotifyClient{}, mockedCache, spotify.Config{})
+
+	mockedCache.On("Get", mock.Anything, "spotify:generation").
+		Return("3", nil).
+		Once()
+	require.NoError(t, s.RefreshGeneration(context.Background()))
+	assert.Equal(t, int64(3), s.Generation())
+
+	// The generation never goes back, e.g. once evicted from the cache
+	mockedCache.On("Get", mock.Anything, "spotify:generation").
+		Return("", spotify.ErrCacheMiss).
+		Once()
+	require.NoError(t, s.RefreshGeneration(context.Background()))
+	assert.Equal(t, int64(3), s.Generation())
+
+	mockedCache.On("Get", mock.Anything, "spotify:generation").
+		Return("invalid", nil).
+		Once()
+	assert.Error(t, s.RefreshGeneration(context.Background()))
+	assert.Equal(t, int64(3), s.Generation())
+}
+
+func TestSpotifySearchService_DeleteOldGenerations(t *testing.T) {
+	t.Run("without sweeper", func(t *testing.T) {
+		s := spotify.New(otel.Tracer("test"), &mocks.MockSpotifyClient{}, &mocks.MockCache{}, spotify.Config{})
+
+		deleted, err := s.DeleteOldGenerations(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0, deleted)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		mockedCache := &mocks.MockCache{}
+		mockedSweeper := &mocks.MockSweeper{}
+		defer mockedCache.AssertExpectations(t)
+		defer mockedSweeper.AssertExpectations(t)
+
+		s := spotify.New(otel.Tracer("test"), &mocks.MockSpotifyClient{}, mockedCache, spotify.Config{Sweeper: mockedSweeper})
+
+		mockedCache.On("Get", mock.Anything, "spotify:generation").
+			Return("1", nil).
+			Once()
+		require.NoError(t, s.RefreshGeneration(context.Background()))
+
+		keys := map[string]bool{
+			"spotify:artist:::10:0:twice":         true,
+			"spotify:v1:g1:artist:::10:0:twice":   true,
+			"spotify:v2:g0:artist:::10:0:twice":   true,
+			"spotify:vx:g1:artist:::10:0:twice":   true,
+			"spotify:v2:g1:artist:::10:0:twice":   false,
+			"spotify:v3:g0:artist:::10:0:twice":   false,
+			"spotify:generation":                  false,
+			"spotify:v2:g1:id:track:4uLU6hMCjMI7": false,
+		}
+
+		mockedSweeper.On("DeleteMatching", mock.Anything, "spotify:*", mock.Anything).
+			Return(func(_ context.Context, _ string, match func(string) bool) (int, error) {
+				deleted := 0
+				for key, old := range keys {
+					assert.Equal(t, old, match(key), key)
+					if match(key) {
+						deleted++
+					}
+				}
+				return deleted, nil
+			}).
+			Once()
+
+		deleted, err := s.DeleteOldGenerations(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 4, deleted)
+	})
+
+	t.Run("sweeper error", func(t *testing.T) {
+		mockedSweeper := &mocks.MockSweeper{}
+		defer mockedSweeper.AssertExpectations(t)
+
+		s := spotify.New(otel.Tracer("test"), &mocks.MockSpotifyClient{}, &mocks.MockCache{}, spotify.Config{Sweeper: mockedSweeper})
+
+		mockedSweeper.On("DeleteMatching", mock.Anything, "spotify:*", mock.Anything).
+			Return(2, errors.New("connection refused")).
+			Once()
+
+		deleted, err := s.DeleteOldGenerations(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, 2, deleted)
+	})
+}
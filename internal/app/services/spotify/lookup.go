@@ -0,0 +1,138 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// LookupTypes are the types that can be looked up by artist and name.
+var LookupTypes = []string{"track", "album"}
+
+// Parts of a title that scrobblers often add and Spotify doesn't have, such
+// as "(feat. X)", "[Live]" or "- Remastered 2011".
+var (
+	titleBracketsPattern = regexp.MustCompile(`\s*[(\[][^)\]]*[)\]]`)
+	titleSuffixPattern   = regexp.MustCompile(`\s+-\s+.*$`)
+)
+
+type lookupQuery struct {
+	query   string
+	filters SearchFilters
+}
+
+// Lookup returns the track or album with the given name by the given artist.
+// It first searches with the artist and name filters, then falls back to a
+// relaxed free-text search. Only results credited to the artist are kept.
+func (s SpotifySearchService) Lookup(ctx context.Context, lookupType string, artist string, name string, opts SearchOptions, matchOpts MatchOptions) (*Match, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.Lookup")
+	defer span.End()
+
+	if !slices.Contains(LookupTypes, lookupType) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidQueryType, lookupType)
+	}
+
+	artist, name = strings.TrimSpace(artist), strings.TrimSpace(name)
+	if artist == "" || name == "" {
+		return nil, fmt.Errorf("%w: artist and %s are required", ErrInvalidQuery, lookupType)
+	}
+
+	if err := matchOpts.validate(); err != nil {
+		return nil, err
+	}
+
+	opts.Limit, opts.Offset = matchCandidates, 0
+
+	opts, err := s.searchOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("type", lookupType),
+		attribute.String("market", opts.Market),
+	)
+
+	for attempt, lookup := range lookupQueries(lookupType, artist, name) {
+		opts.Filters = lookup.filters
+
+		pages, err := s.search(ctx, lookup.query, []string{lookupType}, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []domain.Item
+		for _, item := range pages[lookupType].Items {
+			if creditsArtist(item, artist) {
+				items = append(items, item)
+			}
+		}
+
+		if match := bestMatch(items, name, matchOpts); match != nil {
+			span.SetAttributes(
+				attribute.Bool("relaxed", attempt > 0),
+				attribute.Float64("confidence", match.Confidence),
+			)
+			return match, nil
+		}
+	}
+
+	return nil, ErrNoResultsFound
+}
+
+// lookupQueries returns the strict query, using Spotify's filters, followed
+// by the relaxed one.
+func lookupQueries(lookupType string, artist string, name string) []lookupQuery {
+	strict := SearchFilters{Artist: artist}
+	if lookupType == "track" {
+		strict.Track = name
+	} else {
+		strict.Album = name
+	}
+
+	// The free-text query is unescaped by buildQuery, so it is escaped here
+	// to keep names such as "+44" intact.
+	relaxed := url.QueryEscape(artist + " " + relaxTitle(name))
+
+	return []lookupQuery{
+		{filters: strict},
+		{query: relaxed},
+	}
+}
+
+// relaxTitle removes the bracketed parts and the dash suffix of a title,
+// unless nothing would be left.
+func relaxTitle(title string) string {
+	relaxed := titleBracketsPattern.ReplaceAllString(title, "")
+	relaxed = strings.TrimSpace(titleSuffixPattern.ReplaceAllString(relaxed, ""))
+	if relaxed == "" {
+		return title
+	}
+	return relaxed
+}
+
+// creditsArtist reports whether the artist is one of the artists of the
+// track or album, ignoring case, accents and punctuation.
+func creditsArtist(item domain.Item, artist string) bool {
+	var artists []domain.SimpleArtist
+	switch item := item.(type) {
+	case *domain.Track:
+		artists = item.Artists
+	case *domain.Album:
+		artists = item.Artists
+	}
+
+	artist = normalizeName(artist)
+	for _, credited := range artists {
+		if normalizeName(credited.Name) == artist {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package spotify_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func newCreditedItem(t *testing.T, itemType string, id string, name string, artist string) domain.Item {
+	item, err := domain.UnmarshalItem([]byte(fmt.Sprintf(
+		`{"type": %q, "id": %q, "name": %q, "artists": [{"type": "artist", "name": %q}]}`,
+		itemType, id, name, artist,
+	)))
+	require.NoError(t, err)
+	return item
+}
+
+func TestSpotifySearchService_Lookup(t *testing.T) {
+	opts := spotify.SearchOptions{Limit: 10}
+
+	type searchResult struct {
+		query string
+		key   string
+		items []domain.Item
+	}
+
+	tests := []struct {
+		name        string
+		lookupType  string
+		artist      string
+		title       string
+		searches    []searchResult
+		expectedID  string
+		expectedErr error
+	}{
+		{
+			name:       "credited artist",
+			lookupType: "track",
+			artist:     "TWICE",
+			title:      "FANCY",
+			searches: []searchResult{
+				{
+					query: "artist:TWICE track:FANCY",
+					key:   "artist:twice track:fancy",
+					items: []domain.Item{
+						newCreditedItem(t, "track", "cover", "FANCY", "TWICE Tribute Band"),
+						newCreditedItem(t, "track", "fancy", "FANCY", "TWICE"),
+					},
+				},
+			},
+			expectedID: "fancy",
+		},
+		{
+			name:       "album",
+			lookupType: "album",
+			artist:     "twice",
+			title:      "Formula of Love: O+T=<3",
+			searches: []searchResult{
+				{
+					query: `artist:twice album:"Formula of Love: O+T=<3"`,
+					key:   `artist:twice album:"formula of love: o+t=<3"`,
+					items: []domain.Item{newCreditedItem(t, "album", "formula", "Formula of Love: O+T=<3", "TWICE")},
+				},
+			},
+			expectedID: "formula",
+		},
+		{
+			name:       "relaxed query",
+			lookupType: "track",
+			artist:     "TWICE",
+			title:      "FANCY (feat. Nobody) - Remastered",
+			searches: []searchResult{
+				{
+					query: `artist:TWICE track:"FANCY (feat. Nobody) - Remastered"`,
+					key:   `artist:twice track:"fancy (feat. nobody) - remastered"`,
+					items: []domain.Item{},
+				},
+				{
+					query: "TWICE FANCY",
+					key:   "twice fancy",
+					items: []domain.Item{
+						newCreditedItem(t, "track", "other", "FANCY", "Someone Else"),
+						newCreditedItem(t, "track", "fancy", "FANCY", "TWICE"),
+					},
+				},
+			},
+			expectedID: "fancy",
+		},
+		{
+			name:       "artist not credited",
+			lookupType: "track",
+			artist:     "TWICE",
+			title:      "FANCY",
+			searches: []searchResult{
+				{
+					query: "artist:TWICE track:FANCY",
+					key:   "artist:twice track:fancy",
+					items: []domain.Item{newCreditedItem(t, "track", "cover", "FANCY", "TWICE Tribute Band")},
+				},
+				{
+					query: "TWICE FANCY",
+					key:   "twice fancy",
+					items: []domain.Item{newCreditedItem(t, "track", "cover", "FANCY", "TWICE Tribute Band")},
+				},
+			},
+			expectedErr: spotify.ErrNoResultsFound,
+		},
+		{
+			name:        "invalid type",
+			lookupType:  "artist",
+			artist:      "TWICE",
+			title:       "TWICE",
+			expectedErr: spotify.ErrInvalidQueryType,
+		},
+		{
+			name:        "missing artist",
+			lookupType:  "track",
+			artist:      " ",
+			title:       "FANCY",
+			expectedErr: spotify.ErrInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedSpotifyClient := &mocks.MockSpotifyClient{}
+			mockedCache := &mocks.MockCache{}
+			t.Cleanup(func() {
+				mockedCache.AssertExpectations(t)
+				mockedSpotifyClient.AssertExpectations(t)
+			})
+
+			s := spotify.New(
+				otel.Tracer("test"),
+				mockedSpotifyClient,
+				mockedCache,
+				spotify.Config{},
+			)
+
+			for _, search := range tt.searches {
+				key := "spotify:v2:g0:" + tt.lookupType + ":::10:0:" + search.key
+
+				mockedCache.On("Get", mock.Anything, key).
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				mockedSpotifyClient.On("Search", mock.Anything, search.query, []string{tt.lookupType}, opts).
+					Return(map[string]*spotify.SearchPage{
+						tt.lookupType: spotify.NewSearchPage(search.items, len(search.items), opts),
+					}, nil).
+					Once()
+				if len(search.items) > 0 {
+					mockedCache.On("Set", mock.Anything, key, mock.Anything, time.Hour*24).
+						Return(nil).
+						Once()
+				} else {
+					mockedCache.On("Set", mock.Anything, key, []byte(domain.NotFoundCacheValue), spotify.DefaultNegativeCacheTTL).
+						Return(nil).
+						Once()
+				}
+			}
+
+			match, err := s.Lookup(context.Background(), tt.lookupType, tt.artist, tt.title, spotify.SearchOptions{}, spotify.MatchOptions{})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedID, match.Item.ObjectID())
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package spotify
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// matchCandidates is the number of results the best match is picked from.
+const matchCandidates = 10
+
+// Weights of the match score, which add up to 1 for an exact match of the
+// first and most popular result.
+const (
+	similarityWeight = 0.55
+	popularityWeight = 0.15
+	rankWeight       = 0.1
+	exactMatchBonus  = 0.2
+	// Penalty for covers, karaoke versions and such that the query didn't
+	// ask for.
+	noisePenalty = 0.25
+)
+
+var noiseWords = []string{
+	"tribute",
+	"karaoke",
+	"cover",
+	"covers",
+	"instrumental",
+	"originally performed",
+	"in the style of",
+	"made famous",
+	"8 bit",
+	"lullaby",
+}
+
+type MatchOptions struct {
+	// MinConfidence turns matches below this confidence into
+	// ErrNoResultsFound.
+	MinConfidence float64
+	// Explain lists all the scored candidates in the match.
+	Explain bool
+}
+
+func (o MatchOptions) validate() error {
+	if math.IsNaN(o.MinConfidence) || o.MinConfidence < 0 || o.MinConfidence > 1 {
+		return fmt.Errorf("%w: must be between 0 and 1", ErrInvalidMinConfidence)
+	}
+	return nil
+}
+
+// Match is the best result for a query. It is encoded as the item itself,
+// with the confidence and the candidates added to it.
+type Match struct {
+	Item       domain.Item
+	Confidence float64
+	Candidates []Candidate
+}
+
+type Candidate struct {
+	Item       domain.Item `json:"item"`
+	Score      float64     `json:"score"`
+	Similarity float64     `json:"similarity"`
+	Exact      bool        `json:"exact"`
+	Noise      bool        `json:"noise"`
+}
+
+func (m Match) MarshalJSON() ([]byte, error) {
+	item, err := json.Marshal(m.Item)
+	if err != nil {
+		return nil, err
+	}
+
+	extra, err := json.Marshal(struct {
+		Confidence float64     `json:"confidence"`
+		Candidates []Candidate `json:"candidates,omitempty"`
+	}{
+		Confidence: m.Confidence,
+		Candidates: m.Candidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(item) <= 2 {
+		return extra, nil
+	}
+
+	// Both are objects, so merge them by replacing the closing brace of the
+	// item with the fields of the other one.
+	merged := append(item[:len(item)-1], ',')
+	return append(merged, extra[1:]...), nil
+}
+
+// bestMatch scores the items against the name that was searched for, and
+// returns the best one. It returns nil if there are no items or if the best
+// one is below the minimum confidence.
+func bestMatch(items []domain.Item, target string, opts MatchOptions) *Match {
+	if len(items) == 0 {
+		return nil
+	}
+
+	target = normalizeName(target)
+
+	candidates := make([]Candidate, 0, len(items))
+	for i, item := range items {
+		candidates = append(candidates, scoreCandidate(item, target, i, len(items)))
+	}
+
+	// Stable, so that Spotify's order breaks ties
+	slices.SortStableFunc(candidates, func(a, b Candidate) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+
+	best := candidates[0]
+	if best.Score < opts.MinConfidence {
+		return nil
+	}
+
+	match := &Match{
+		Item:       best.Item,
+		Confidence: best.Score,
+	}
+	if opts.Explain {
+		match.Candidates = candidates
+	}
+	return match
+}
+
+func scoreCandidate(item domain.Item, target string, rank int, total int) Candidate {
+	name := normalizeName(item.ObjectName())
+
+	candidate := Candidate{
+		Item:       item,
+		Similarity: similarity(name, target),
+		Exact:      target != "" && name == target,
+	}
+
+	for _, word := range noiseWords {
+		if containsWords(name, word) && !containsWords(target, word) {
+			candidate.Noise = true
+			break
+		}
+	}
+
+	weight := similarityWeight
+	var score float64
+
+	popularity, hasPopularity := itemPopularity(item)
+	if hasPopularity {
+		score += popularityWeight * float64(popularity) / 100
+	} else {
+		weight += popularityWeight
+	}
+
+	score += weight * candidate.Similarity
+	score += rankWeight * float64(total-rank) / float64(total)
+	if candidate.Exact {
+		score += exactMatchBonus
+	}
+	if candidate.Noise {
+		score -= noisePenalty
+	}
+
+	candidate.Score = math.Round(min(max(score, 0), 1)*1000) / 1000
+	candidate.Similarity = math.Round(candidate.Similarity*1000) / 1000
+
+	return candidate
+}
+
+func itemPopularity(item domain.Item) (int, bool) {
+	switch item := item.(type) {
+	case *domain.Artist:
+		return item.Popularity, true
+	case *domain.Track:
+		return item.Popularity, true
+	}
+	return 0, false
+}
+
+// matchTarget returns the name the results should be compared to: the
+// filter for the searched type if there is one, or the free-text query.
+func matchTarget(query string, filters SearchFilters, searchType string) string {
+	switch {
+	case searchType == "artist" && filters.Artist != "":
+		return filters.Artist
+	case searchType == "album" && filters.Album != "":
+		return filters.Album
+	case searchType == "track" && filters.Track != "":
+		return filters.Track
+	}
+
+	if decoded, err := url.QueryUnescape(query); err == nil {
+		return decoded
+	}
+	return query
+}
+
+// stripDiacritics returns a transformer removing the diacritics. Transformers
+// keep state between calls, so a new one is needed for each string.
+func stripDiacritics() transform.Transformer {
+	return transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+}
+
+// normalizeName lowercases the name, removes diacritics and replaces
+// punctuation with spaces.
+func normalizeName(name string) string {
+	if stripped, _, err := transform.String(stripDiacritics(), name); err == nil {
+		name = stripped
+	}
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, name)
+
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func containsWords(name string, words string) bool {
+	return strings.Contains(" "+name+" ", " "+words+" ")
+}
+
+// similarity is 1 minus the edit distance between a and b, relative to the
+// length of the longest one.
+func similarity(a string, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -80,6 +80,113 @@ func (_c *MockCache_Get_Call) RunAndReturn(run func(context.Context, string) (st
 	return _c
 }
 
+// MGet provides a mock function with given fields: ctx, keys
+func (_m *MockCache) MGet(ctx context.Context, keys []string) ([]string, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MGet")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCache_MGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MGet'
+type MockCache_MGet_Call struct {
+	*mock.Call
+}
+
+// MGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockCache_Expecter) MGet(ctx interface{}, keys interface{}) *MockCache_MGet_Call {
+	return &MockCache_MGet_Call{Call: _e.mock.On("MGet", ctx, keys)}
+}
+
+func (_c *MockCache_MGet_Call) Run(run func(ctx context.Context, keys []string)) *MockCache_MGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockCache_MGet_Call) Return(_a0 []string, _a1 error) *MockCache_MGet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCache_MGet_Call) RunAndReturn(run func(context.Context, []string) ([]string, error)) *MockCache_MGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MSet provides a mock function with given fields: ctx, values, ttl
+func (_m *MockCache) MSet(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	ret := _m.Called(ctx, values, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MSet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string][]byte, time.Duration) error); ok {
+		r0 = rf(ctx, values, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCache_MSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MSet'
+type MockCache_MSet_Call struct {
+	*mock.Call
+}
+
+// MSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - values map[string][]byte
+//   - ttl time.Duration
+func (_e *MockCache_Expecter) MSet(ctx interface{}, values interface{}, ttl interface{}) *MockCache_MSet_Call {
+	return &MockCache_MSet_Call{Call: _e.mock.On("MSet", ctx, values, ttl)}
+}
+
+func (_c *MockCache_MSet_Call) Run(run func(ctx context.Context, values map[string][]byte, ttl time.Duration)) *MockCache_MSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[string][]byte), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockCache_MSet_Call) Return(_a0 error) *MockCache_MSet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_MSet_Call) RunAndReturn(run func(context.Context, map[string][]byte, time.Duration) error) *MockCache_MSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Set provides a mock function with given fields: ctx, key, value, ttl
 func (_m *MockCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
 	ret := _m.Called(ctx, key, value, ttl)
@@ -0,0 +1,105 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockLocker is an autogenerated mock type for the Locker type
+type MockLocker struct {
+	mock.Mock
+}
+
+type MockLocker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLocker) EXPECT() *MockLocker_Expecter {
+	return &MockLocker_Expecter{mock: &_m.Mock}
+}
+
+// TryLock provides a mock function with given fields: ctx, key, ttl
+func (_m *MockLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (func(context.Context) error, bool, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryLock")
+	}
+
+	var r0 func(context.Context) error
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (func(context.Context) error, bool, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) func(context.Context) error); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func(context.Context) error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) bool); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = rf(ctx, key, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockLocker_TryLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TryLock'
+type MockLocker_TryLock_Call struct {
+	*mock.Call
+}
+
+// TryLock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+func (_e *MockLocker_Expecter) TryLock(ctx interface{}, key interface{}, ttl interface{}) *MockLocker_TryLock_Call {
+	return &MockLocker_TryLock_Call{Call: _e.mock.On("TryLock", ctx, key, ttl)}
+}
+
+func (_c *MockLocker_TryLock_Call) Run(run func(ctx context.Context, key string, ttl time.Duration)) *MockLocker_TryLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockLocker_TryLock_Call) Return(unlock func(context.Context) error, acquired bool, err error) *MockLocker_TryLock_Call {
+	_c.Call.Return(unlock, acquired, err)
+	return _c
+}
+
+func (_c *MockLocker_TryLock_Call) RunAndReturn(run func(context.Context, string, time.Duration) (func(context.Context) error, bool, error)) *MockLocker_TryLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLocker creates a new instance of MockLocker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLocker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLocker {
+	mock := &MockLocker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockShortLinkResolver is an autogenerated mock type for the ShortLinkResolver type
+type MockShortLinkResolver struct {
+	mock.Mock
+}
+
+type MockShortLinkResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockShortLinkResolver) EXPECT() *MockShortLinkResolver_Expecter {
+	return &MockShortLinkResolver_Expecter{mock: &_m.Mock}
+}
+
+// Resolve provides a mock function with given fields: ctx, link
+func (_m *MockShortLinkResolver) Resolve(ctx context.Context, link string) (string, error) {
+	ret := _m.Called(ctx, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, link)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, link)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShortLinkResolver_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockShortLinkResolver_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link string
+func (_e *MockShortLinkResolver_Expecter) Resolve(ctx interface{}, link interface{}) *MockShortLinkResolver_Resolve_Call {
+	return &MockShortLinkResolver_Resolve_Call{Call: _e.mock.On("Resolve", ctx, link)}
+}
+
+func (_c *MockShortLinkResolver_Resolve_Call) Run(run func(ctx context.Context, link string)) *MockShortLinkResolver_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockShortLinkResolver_Resolve_Call) Return(_a0 string, _a1 error) *MockShortLinkResolver_Resolve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortLinkResolver_Resolve_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockShortLinkResolver_Resolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockShortLinkResolver creates a new instance of MockShortLinkResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockShortLinkResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockShortLinkResolver {
+	mock := &MockShortLinkResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
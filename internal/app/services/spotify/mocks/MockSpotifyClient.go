@@ -5,7 +5,10 @@ package mocks
 import (
 	context "context"
 
+	domain "github.com/angristan/spotify-search-proxy/internal/app/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	spotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
 )
 
 // MockSpotifyClient is an autogenerated mock type for the SpotifyClient type
@@ -21,29 +24,449 @@ func (_m *MockSpotifyClient) EXPECT() *MockSpotifyClient_Expecter {
 	return &MockSpotifyClient_Expecter{mock: &_m.Mock}
 }
 
-// Search provides a mock function with given fields: ctx, query, searchType
-func (_m *MockSpotifyClient) Search(ctx context.Context, query string, searchType string) (interface{}, error) {
-	ret := _m.Called(ctx, query, searchType)
+// GetAlbumTracks provides a mock function with given fields: ctx, id, market, maxTracks
+func (_m *MockSpotifyClient) GetAlbumTracks(ctx context.Context, id string, market string, maxTracks int) (*spotify.Tracklist, error) {
+	ret := _m.Called(ctx, id, market, maxTracks)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Search")
+		panic("no return value specified for GetAlbumTracks")
+	}
+
+	var r0 *spotify.Tracklist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) (*spotify.Tracklist, error)); ok {
+		return rf(ctx, id, market, maxTracks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) *spotify.Tracklist); ok {
+		r0 = rf(ctx, id, market, maxTracks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*spotify.Tracklist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, id, market, maxTracks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyClient_GetAlbumTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlbumTracks'
+type MockSpotifyClient_GetAlbumTracks_Call struct {
+	*mock.Call
+}
+
+// GetAlbumTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - market string
+//   - maxTracks int
+func (_e *MockSpotifyClient_Expecter) GetAlbumTracks(ctx interface{}, id interface{}, market interface{}, maxTracks interface{}) *MockSpotifyClient_GetAlbumTracks_Call {
+	return &MockSpotifyClient_GetAlbumTracks_Call{Call: _e.mock.On("GetAlbumTracks", ctx, id, market, maxTracks)}
+}
+
+func (_c *MockSpotifyClient_GetAlbumTracks_Call) Run(run func(ctx context.Context, id string, market string, maxTracks int)) *MockSpotifyClient_GetAlbumTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetAlbumTracks_Call) Return(_a0 *spotify.Tracklist, _a1 error) *MockSpotifyClient_GetAlbumTracks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetAlbumTracks_Call) RunAndReturn(run func(context.Context, string, string, int) (*spotify.Tracklist, error)) *MockSpotifyClient_GetAlbumTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAlbums provides a mock function with given fields: ctx, ids, market
+func (_m *MockSpotifyClient) GetAlbums(ctx context.Context, ids []string, market string) ([]domain.Item, error) {
+	ret := _m.Called(ctx, ids, market)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbums")
+	}
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) ([]domain.Item, error)); ok {
+		return rf(ctx, ids, market)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) []domain.Item); ok {
+		r0 = rf(ctx, ids, market)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = rf(ctx, ids, market)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyClient_GetAlbums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlbums'
+type MockSpotifyClient_GetAlbums_Call struct {
+	*mock.Call
+}
+
+// GetAlbums is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+//   - market string
+func (_e *MockSpotifyClient_Expecter) GetAlbums(ctx interface{}, ids interface{}, market interface{}) *MockSpotifyClient_GetAlbums_Call {
+	return &MockSpotifyClient_GetAlbums_Call{Call: _e.mock.On("GetAlbums", ctx, ids, market)}
+}
+
+func (_c *MockSpotifyClient_GetAlbums_Call) Run(run func(ctx context.Context, ids []string, market string)) *MockSpotifyClient_GetAlbums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetAlbums_Call) Return(_a0 []domain.Item, _a1 error) *MockSpotifyClient_GetAlbums_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetAlbums_Call) RunAndReturn(run func(context.Context, []string, string) ([]domain.Item, error)) *MockSpotifyClient_GetAlbums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtistAlbums provides a mock function with given fields: ctx, id, includeGroups, opts
+func (_m *MockSpotifyClient) GetArtistAlbums(ctx context.Context, id string, includeGroups []string, opts spotify.SearchOptions) (*spotify.SearchPage, error) {
+	ret := _m.Called(ctx, id, includeGroups, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtistAlbums")
+	}
+
+	var r0 *spotify.SearchPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SearchOptions) (*spotify.SearchPage, error)); ok {
+		return rf(ctx, id, includeGroups, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SearchOptions) *spotify.SearchPage); ok {
+		r0 = rf(ctx, id, includeGroups, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*spotify.SearchPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, spotify.SearchOptions) error); ok {
+		r1 = rf(ctx, id, includeGroups, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyClient_GetArtistAlbums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtistAlbums'
+type MockSpotifyClient_GetArtistAlbums_Call struct {
+	*mock.Call
+}
+
+// GetArtistAlbums is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - includeGroups []string
+//   - opts spotify.SearchOptions
+func (_e *MockSpotifyClient_Expecter) GetArtistAlbums(ctx interface{}, id interface{}, includeGroups interface{}, opts interface{}) *MockSpotifyClient_GetArtistAlbums_Call {
+	return &MockSpotifyClient_GetArtistAlbums_Call{Call: _e.mock.On("GetArtistAlbums", ctx, id, includeGroups, opts)}
+}
+
+func (_c *MockSpotifyClient_GetArtistAlbums_Call) Run(run func(ctx context.Context, id string, includeGroups []string, opts spotify.SearchOptions)) *MockSpotifyClient_GetArtistAlbums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(spotify.SearchOptions))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetArtistAlbums_Call) Return(_a0 *spotify.SearchPage, _a1 error) *MockSpotifyClient_GetArtistAlbums_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetArtistAlbums_Call) RunAndReturn(run func(context.Context, string, []string, spotify.SearchOptions) (*spotify.SearchPage, error)) *MockSpotifyClient_GetArtistAlbums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtistTopTracks provides a mock function with given fields: ctx, id, market
+func (_m *MockSpotifyClient) GetArtistTopTracks(ctx context.Context, id string, market string) ([]domain.Item, error) {
+	ret := _m.Called(ctx, id, market)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtistTopTracks")
 	}
 
-	var r0 interface{}
+	var r0 []domain.Item
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (interface{}, error)); ok {
-		return rf(ctx, query, searchType)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.Item, error)); ok {
+		return rf(ctx, id, market)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) interface{}); ok {
-		r0 = rf(ctx, query, searchType)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domain.Item); ok {
+		r0 = rf(ctx, id, market)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
+			r0 = ret.Get(0).([]domain.Item)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, query, searchType)
+		r1 = rf(ctx, id, market)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyClient_GetArtistTopTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtistTopTracks'
+type MockSpotifyClient_GetArtistTopTracks_Call struct {
+	*mock.Call
+}
+
+// GetArtistTopTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - market string
+func (_e *MockSpotifyClient_Expecter) GetArtistTopTracks(ctx interface{}, id interface{}, market interface{}) *MockSpotifyClient_GetArtistTopTracks_Call {
+	return &MockSpotifyClient_GetArtistTopTracks_Call{Call: _e.mock.On("GetArtistTopTracks", ctx, id, market)}
+}
+
+func (_c *MockSpotifyClient_GetArtistTopTracks_Call) Run(run func(ctx context.Context, id string, market string)) *MockSpotifyClient_GetArtistTopTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetArtistTopTracks_Call) Return(_a0 []domain.Item, _a1 error) *MockSpotifyClient_GetArtistTopTracks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetArtistTopTracks_Call) RunAndReturn(run func(context.Context, string, string) ([]domain.Item, error)) *MockSpotifyClient_GetArtistTopTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtists provides a mock function with given fields: ctx, ids
+func (_m *MockSpotifyClient) GetArtists(ctx context.Context, ids []string) ([]domain.Item, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtists")
+	}
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domain.Item, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []domain.Item); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyClient_GetArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtists'
+type MockSpotifyClient_GetArtists_Call struct {
+	*mock.Call
+}
+
+// GetArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *MockSpotifyClient_Expecter) GetArtists(ctx interface{}, ids interface{}) *MockSpotifyClient_GetArtists_Call {
+	return &MockSpotifyClient_GetArtists_Call{Call: _e.mock.On("GetArtists", ctx, ids)}
+}
+
+func (_c *MockSpotifyClient_GetArtists_Call) Run(run func(ctx context.Context, ids []string)) *MockSpotifyClient_GetArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetArtists_Call) Return(_a0 []domain.Item, _a1 error) *MockSpotifyClient_GetArtists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetArtists_Call) RunAndReturn(run func(context.Context, []string) ([]domain.Item, error)) *MockSpotifyClient_GetArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRelatedArtists provides a mock function with given fields: ctx, id
+func (_m *MockSpotifyClient) GetRelatedArtists(ctx context.Context, id string) ([]domain.Item, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRelatedArtists")
+	}
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Item, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Item); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyClient_GetRelatedArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRelatedArtists'
+type MockSpotifyClient_GetRelatedArtists_Call struct {
+	*mock.Call
+}
+
+// GetRelatedArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockSpotifyClient_Expecter) GetRelatedArtists(ctx interface{}, id interface{}) *MockSpotifyClient_GetRelatedArtists_Call {
+	return &MockSpotifyClient_GetRelatedArtists_Call{Call: _e.mock.On("GetRelatedArtists", ctx, id)}
+}
+
+func (_c *MockSpotifyClient_GetRelatedArtists_Call) Run(run func(ctx context.Context, id string)) *MockSpotifyClient_GetRelatedArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetRelatedArtists_Call) Return(_a0 []domain.Item, _a1 error) *MockSpotifyClient_GetRelatedArtists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetRelatedArtists_Call) RunAndReturn(run func(context.Context, string) ([]domain.Item, error)) *MockSpotifyClient_GetRelatedArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTracks provides a mock function with given fields: ctx, ids, market
+func (_m *MockSpotifyClient) GetTracks(ctx context.Context, ids []string, market string) ([]domain.Item, error) {
+	ret := _m.Called(ctx, ids, market)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTracks")
+	}
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) ([]domain.Item, error)); ok {
+		return rf(ctx, ids, market)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) []domain.Item); ok {
+		r0 = rf(ctx, ids, market)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = rf(ctx, ids, market)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyClient_GetTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTracks'
+type MockSpotifyClient_GetTracks_Call struct {
+	*mock.Call
+}
+
+// GetTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+//   - market string
+func (_e *MockSpotifyClient_Expecter) GetTracks(ctx interface{}, ids interface{}, market interface{}) *MockSpotifyClient_GetTracks_Call {
+	return &MockSpotifyClient_GetTracks_Call{Call: _e.mock.On("GetTracks", ctx, ids, market)}
+}
+
+func (_c *MockSpotifyClient_GetTracks_Call) Run(run func(ctx context.Context, ids []string, market string)) *MockSpotifyClient_GetTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetTracks_Call) Return(_a0 []domain.Item, _a1 error) *MockSpotifyClient_GetTracks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyClient_GetTracks_Call) RunAndReturn(run func(context.Context, []string, string) ([]domain.Item, error)) *MockSpotifyClient_GetTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, query, searchTypes, opts
+func (_m *MockSpotifyClient) Search(ctx context.Context, query string, searchTypes []string, opts spotify.SearchOptions) (map[string]*spotify.SearchPage, error) {
+	ret := _m.Called(ctx, query, searchTypes, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 map[string]*spotify.SearchPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SearchOptions) (map[string]*spotify.SearchPage, error)); ok {
+		return rf(ctx, query, searchTypes, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SearchOptions) map[string]*spotify.SearchPage); ok {
+		r0 = rf(ctx, query, searchTypes, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*spotify.SearchPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, spotify.SearchOptions) error); ok {
+		r1 = rf(ctx, query, searchTypes, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,24 +482,25 @@ type MockSpotifyClient_Search_Call struct {
 // Search is a helper method to define mock.On call
 //   - ctx context.Context
 //   - query string
-//   - searchType string
-func (_e *MockSpotifyClient_Expecter) Search(ctx interface{}, query interface{}, searchType interface{}) *MockSpotifyClient_Search_Call {
-	return &MockSpotifyClient_Search_Call{Call: _e.mock.On("Search", ctx, query, searchType)}
+//   - searchTypes []string
+//   - opts spotify.SearchOptions
+func (_e *MockSpotifyClient_Expecter) Search(ctx interface{}, query interface{}, searchTypes interface{}, opts interface{}) *MockSpotifyClient_Search_Call {
+	return &MockSpotifyClient_Search_Call{Call: _e.mock.On("Search", ctx, query, searchTypes, opts)}
 }
 
-func (_c *MockSpotifyClient_Search_Call) Run(run func(ctx context.Context, query string, searchType string)) *MockSpotifyClient_Search_Call {
+func (_c *MockSpotifyClient_Search_Call) Run(run func(ctx context.Context, query string, searchTypes []string, opts spotify.SearchOptions)) *MockSpotifyClient_Search_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(spotify.SearchOptions))
 	})
 	return _c
 }
 
-func (_c *MockSpotifyClient_Search_Call) Return(_a0 interface{}, _a1 error) *MockSpotifyClient_Search_Call {
+func (_c *MockSpotifyClient_Search_Call) Return(_a0 map[string]*spotify.SearchPage, _a1 error) *MockSpotifyClient_Search_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSpotifyClient_Search_Call) RunAndReturn(run func(context.Context, string, string) (interface{}, error)) *MockSpotifyClient_Search_Call {
+func (_c *MockSpotifyClient_Search_Call) RunAndReturn(run func(context.Context, string, []string, spotify.SearchOptions) (map[string]*spotify.SearchPage, error)) *MockSpotifyClient_Search_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSweeper is an autogenerated mock type for the Sweeper type
+type MockSweeper struct {
+	mock.Mock
+}
+
+type MockSweeper_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSweeper) EXPECT() *MockSweeper_Expecter {
+	return &MockSweeper_Expecter{mock: &_m.Mock}
+}
+
+// DeleteMatching provides a mock function with given fields: ctx, pattern, match
+func (_m *MockSweeper) DeleteMatching(ctx context.Context, pattern string, match func(string) bool) (int, error) {
+	ret := _m.Called(ctx, pattern, match)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMatching")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(string) bool) (int, error)); ok {
+		return rf(ctx, pattern, match)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(string) bool) int); ok {
+		r0 = rf(ctx, pattern, match)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, func(string) bool) error); ok {
+		r1 = rf(ctx, pattern, match)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSweeper_DeleteMatching_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMatching'
+type MockSweeper_DeleteMatching_Call struct {
+	*mock.Call
+}
+
+// DeleteMatching is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+//   - match func(string) bool
+func (_e *MockSweeper_Expecter) DeleteMatching(ctx interface{}, pattern interface{}, match interface{}) *MockSweeper_DeleteMatching_Call {
+	return &MockSweeper_DeleteMatching_Call{Call: _e.mock.On("DeleteMatching", ctx, pattern, match)}
+}
+
+func (_c *MockSweeper_DeleteMatching_Call) Run(run func(ctx context.Context, pattern string, match func(string) bool)) *MockSweeper_DeleteMatching_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(func(string) bool))
+	})
+	return _c
+}
+
+func (_c *MockSweeper_DeleteMatching_Call) Return(_a0 int, _a1 error) *MockSweeper_DeleteMatching_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSweeper_DeleteMatching_Call) RunAndReturn(run func(context.Context, string, func(string) bool) (int, error)) *MockSweeper_DeleteMatching_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSweeper creates a new instance of MockSweeper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSweeper(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSweeper {
+	mock := &MockSweeper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,32 @@
+package spotify
+
+import (
+	"context"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+)
+
+// DefaultNegativeCacheTTL is short, as a query without results may only be
+// missing a release that is about to come out.
+const DefaultNegativeCacheTTL = time.Minute * 15
+
+type bypassNegativeCacheKey struct{}
+
+// WithoutNegativeCache returns a context whose requests search Spotify again
+// instead of returning cached not-found outcomes. They are still cached again
+// if Spotify still has nothing.
+func WithoutNegativeCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassNegativeCacheKey{}, true)
+}
+
+func negativeCacheBypassed(ctx context.Context) bool {
+	bypassed, _ := ctx.Value(bypassNegativeCacheKey{}).(bool)
+	return bypassed
+}
+
+// isNegative tells whether a cached value is a not-found outcome that must be
+// used, rather than searched again.
+func isNegative(ctx context.Context, value string) bool {
+	return value == domain.NotFoundCacheValue && !negativeCacheBypassed(ctx)
+}
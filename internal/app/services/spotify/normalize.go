@@ -0,0 +1,94 @@
+package spotify
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// QueryNormalization is the steps applied to the text of a search query and
+// of its filters before it is searched and cached, so that equivalent
+// queries such as "Daft%20Punk" and "daft  punk" share their cache entry.
+// Queries are always URL-unescaped first, as the Spotify SDK escapes them
+// again. Case and diacritics are only folded in the cache key.
+type QueryNormalization struct {
+	// NFKC composes accented letters and replaces compatibility characters,
+	// such as full-width letters and ligatures.
+	NFKC bool
+	// FoldCase lower-cases the text, Spotify's search being case-insensitive.
+	FoldCase bool
+	// CollapseWhitespace trims the text and replaces runs of whitespace with
+	// a single space.
+	CollapseWhitespace bool
+	// FoldDiacritics removes accents, so that "Beyonce" and "Beyoncé" share
+	// their cache entry. Spotify usually matches both, but not always ranks
+	// them the same.
+	FoldDiacritics bool
+}
+
+// DefaultQueryNormalization only applies the steps that don't change what
+// Spotify returns.
+var DefaultQueryNormalization = QueryNormalization{
+	NFKC:               true,
+	FoldCase:           true,
+	CollapseWhitespace: true,
+}
+
+// NormalizeQuery unescapes a URL-escaped query, then normalizes it.
+func (n QueryNormalization) NormalizeQuery(query string) (string, error) {
+	query, err := url.QueryUnescape(query)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidQuery, err.Error())
+	}
+
+	return n.normalize(query), nil
+}
+
+func (n QueryNormalization) normalize(text string) string {
+	if n.NFKC {
+		text = norm.NFKC.String(text)
+	}
+	if n.FoldCase {
+		// Casers keep state between calls, so they can't be shared
+		text = cases.Fold().String(text)
+	}
+	if n.FoldDiacritics {
+		if stripped, _, err := transform.String(stripDiacritics(), text); err == nil {
+			text = stripped
+		}
+	}
+	if n.CollapseWhitespace {
+		text = strings.Join(strings.Fields(text), " ")
+	}
+	return text
+}
+
+// upstream returns the steps of the normalization that also apply to the query
+// sent to Spotify.
+func (n QueryNormalization) upstream() QueryNormalization {
+	n.FoldCase = false
+	n.FoldDiacritics = false
+	return n
+}
+
+// normalizeFilters normalizes the free-text filters. The other ones are
+// validated and normalized by buildQuery.
+func (n QueryNormalization) normalizeFilters(filters SearchFilters) SearchFilters {
+	filters.Artist = n.normalize(filters.Artist)
+	filters.Album = n.normalize(filters.Album)
+	filters.Track = n.normalize(filters.Track)
+	filters.Genre = n.normalize(filters.Genre)
+	return filters
+}
+
+// normalization returns the normalization of a search type.
+func (s SpotifySearchService) normalization(searchType string) QueryNormalization {
+	if normalization, ok := s.config.QueryNormalization[searchType]; ok {
+		return normalization
+	}
+	return DefaultQueryNormalization
+}
@@ -0,0 +1,171 @@
+package spotify_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestQueryNormalization_NormalizeQuery(t *testing.T) {
+	withDiacritics := spotify.DefaultQueryNormalization
+	withDiacritics.FoldDiacritics = true
+
+	tests := []struct {
+		name          string
+		normalization spotify.QueryNormalization
+		query         string
+		expected      string
+		expectedErr   error
+	}{
+		{
+			name:          "url escaped",
+			normalization: spotify.DefaultQueryNormalization,
+			query:         "Daft%20Punk+discovery",
+			expected:      "daft punk discovery",
+		},
+		{
+			name:          "decomposed accents",
+			normalization: spotify.DefaultQueryNormalization,
+			query:         "Beyonce\u0301",
+			expected:      "beyonc\u00e9",
+		},
+		{
+			name:          "full-width letters",
+			normalization: spotify.DefaultQueryNormalization,
+			query:         "ＴＷＩＣＥ",
+			expected:      "twice",
+		},
+		{
+			name:          "case folding",
+			normalization: spotify.DefaultQueryNormalization,
+			query:         "Die Ärzte Straße",
+			expected:      "die ärzte strasse",
+		},
+		{
+			name:          "whitespace",
+			normalization: spotify.DefaultQueryNormalization,
+			query:         "  daft \t punk\n",
+			expected:      "daft punk",
+		},
+		{
+			name:          "diacritics kept",
+			normalization: spotify.DefaultQueryNormalization,
+			query:         "Sigur Rós",
+			expected:      "sigur rós",
+		},
+		{
+			name:          "diacritics folded",
+			normalization: withDiacritics,
+			query:         "Sigur Rós",
+			expected:      "sigur ros",
+		},
+		{
+			name:          "decomposed diacritics folded",
+			normalization: withDiacritics,
+			query:         "Beyonce\u0301",
+			expected:      "beyonce",
+		},
+		{
+			name:          "no normalization",
+			normalization: spotify.QueryNormalization{},
+			query:         "  Daft%20Punk ",
+			expected:      "  Daft Punk ",
+		},
+		{
+			name:          "malformed escape",
+			normalization: spotify.DefaultQueryNormalization,
+			query:         "100%",
+			expectedErr:   spotify.ErrInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := tt.normalization.NormalizeQuery(tt.query)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestSpotifySearchService_Search_normalizationPerType(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+	t.Cleanup(func() {
+		mockedCache.AssertExpectations(t)
+		mockedSpotifyClient.AssertExpectations(t)
+	})
+
+	withDiacritics := spotify.DefaultQueryNormalization
+	withDiacritics.FoldDiacritics = true
+
+	s := spotify.New(
+		otel.Tracer("test"),
+		mockedSpotifyClient,
+		mockedCache,
+		spotify.Config{
+			QueryNormalization: map[string]spotify.QueryNormalization{"artist": withDiacritics},
+		},
+	)
+
+	opts := spotify.SearchOptions{Limit: 10}
+
+	mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist:::10:0:sigur ros").
+		Return("", spotify.ErrCacheMiss).
+		Once()
+	mockedCache.On("Get", mock.Anything, "spotify:v2:g0:album:::10:0:sigur rós").
+		Return("", spotify.ErrCacheMiss).
+		Once()
+	// Spotify is sent the query as written, once for both types
+	mockedSpotifyClient.On("Search", mock.Anything, "Sigur Rós", []string{"artist", "album"}, opts).
+		Return(map[string]*spotify.SearchPage{
+			"artist": spotify.NewSearchPage([]domain.Item{newItem(t, "artist", "Sigur Rós")}, 1, opts),
+			"album":  spotify.NewSearchPage([]domain.Item{newItem(t, "album", "Takk...")}, 1, opts),
+		}, nil).
+		Once()
+	mockedCache.On("Set", mock.Anything, mock.Anything, mock.Anything, time.Hour*24).
+		Return(nil).
+		Twice()
+
+	pages, err := s.MultiSearch(context.Background(), "Sigur Rós", []string{"artist", "album"}, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Sigur Rós", pages["artist"].Items[0].ObjectName())
+	assert.Equal(t, "Takk...", pages["album"].Items[0].ObjectName())
+}
+
+func TestQueryNormalization_NormalizeQuery_concurrent(t *testing.T) {
+	normalization := spotify.DefaultQueryNormalization
+	normalization.FoldDiacritics = true
+
+	query := strings.Repeat("Sigur Rós STRASSE ", 64)
+	expected := strings.TrimSpace(strings.Repeat("sigur ros strasse ", 64))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			normalized, err := normalization.NormalizeQuery(query)
+			if assert.NoError(t, err) {
+				assert.Equal(t, expected, normalized)
+			}
+		}()
+	}
+	wg.Wait()
+}
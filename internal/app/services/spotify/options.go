@@ -0,0 +1,57 @@
+package spotify
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	marketPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	localePattern = regexp.MustCompile(`^[a-z]{2,3}(_[A-Z]{2})?$`)
+)
+
+type SearchOptions struct {
+	Limit  int
+	Offset int
+
+	// Market is an ISO 3166-1 alpha-2 country code. Only content playable
+	// in that market is returned.
+	Market string
+	// Locale is an ISO 639 language code, optionally followed by an
+	// underscore and a country code (e.g. es_MX), used for localized names.
+	Locale string
+
+	Filters SearchFilters
+}
+
+func (s SpotifySearchService) searchOptions(opts SearchOptions) (SearchOptions, error) {
+	if opts.Limit == 0 {
+		opts.Limit = DefaultSearchLimit
+	}
+	if opts.Market == "" {
+		opts.Market = s.config.DefaultMarket
+	}
+	opts.Market = strings.ToUpper(opts.Market)
+
+	if err := opts.validate(); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+func (o SearchOptions) validate() error {
+	if o.Limit < 1 || o.Limit > MaxSearchLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidPagination, MaxSearchLimit)
+	}
+	if o.Offset < 0 || o.Offset+o.Limit > MaxSearchOffset {
+		return fmt.Errorf("%w: offset+limit must be between 0 and %d", ErrInvalidPagination, MaxSearchOffset)
+	}
+	if o.Market != "" && !marketPattern.MatchString(o.Market) {
+		return fmt.Errorf("%w: %s", ErrInvalidMarket, o.Market)
+	}
+	if o.Locale != "" && !localePattern.MatchString(o.Locale) {
+		return fmt.Errorf("%w: %s", ErrInvalidLocale, o.Locale)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package spotify
+
+import (
+	"encoding/json"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+)
+
+const (
+	DefaultSearchLimit = 20
+	// Spotify caps the page size of a search at 50 items...
+	MaxSearchLimit = 50
+	// ...and won't page past the 1000th result.
+	MaxSearchOffset = 1000
+)
+
+// SearchPage is a window of search results. Next and Previous are the
+// offsets to request to move through the results, or nil at either end.
+type SearchPage struct {
+	Items    []domain.Item `json:"items"`
+	Total    int           `json:"total"`
+	Limit    int           `json:"limit"`
+	Offset   int           `json:"offset"`
+	Next     *int          `json:"next"`
+	Previous *int          `json:"previous"`
+}
+
+func NewSearchPage(items []domain.Item, total int, opts SearchOptions) *SearchPage {
+	if items == nil {
+		items = []domain.Item{}
+	}
+
+	page := &SearchPage{
+		Items:  items,
+		Total:  total,
+		Limit:  opts.Limit,
+		Offset: opts.Offset,
+	}
+
+	if next := opts.Offset + opts.Limit; next < total && next < MaxSearchOffset {
+		page.Next = &next
+	}
+	if opts.Offset > 0 {
+		previous := max(opts.Offset-opts.Limit, 0)
+		page.Previous = &previous
+	}
+
+	return page
+}
+
+// UnmarshalJSON decodes the items into their concrete types, so that a cached
+// page is encoded exactly like the page it was built from.
+func (p *SearchPage) UnmarshalJSON(data []byte) error {
+	type searchPage SearchPage
+	var page struct {
+		searchPage
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(data, &page); err != nil {
+		return err
+	}
+
+	items := make([]domain.Item, 0, len(page.Items))
+	for _, rawItem := range page.Items {
+		item, err := domain.UnmarshalItem(rawItem)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+	}
+
+	*p = SearchPage(page.searchPage)
+	p.Items = items
+	return nil
+}
@@ -0,0 +1,181 @@
+package spotify
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	yearPattern = regexp.MustCompile(`^(\d{4})(?:-(\d{4}))?$`)
+	isrcPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{3}\d{7}$`)
+	upcPattern  = regexp.MustCompile(`^\d{12,13}$`)
+)
+
+// SearchFilters are Spotify's field filters. They are composed with the
+// free-text query into a single search query.
+type SearchFilters struct {
+	Artist string
+	Album  string
+	Track  string
+	// Year is either a single year (1994) or a range (1990-1999).
+	Year  string
+	Genre string
+	ISRC  string
+	UPC   string
+	// Tag is either "new" (released in the past two weeks) or "hipster"
+	// (lowest 10% popularity).
+	Tag string
+}
+
+func (f SearchFilters) IsZero() bool {
+	return f == SearchFilters{}
+}
+
+// filterTypes lists which search types each filter applies to, according to
+// Spotify's documentation.
+var filterTypes = map[string][]string{
+	"artist": {"album", "artist", "track"},
+	"album":  {"album", "track"},
+	"track":  {"track"},
+	"year":   {"album", "artist", "track"},
+	"genre":  {"artist", "track"},
+	"isrc":   {"track"},
+	"upc":    {"album"},
+	"tag":    {"album"},
+}
+
+// searchQuery is a search query as sent to Spotify, and the fully normalized
+// query its results are cached under.
+type searchQuery struct {
+	text string
+	key  string
+}
+
+// buildQuery decodes and normalizes the free-text query and the filters, then
+// composes them. Case and diacritics are only folded in the key, so that
+// equivalent searches share their cache entry while Spotify still gets the
+// query as written.
+func buildQuery(text string, filters SearchFilters, searchTypes []string, normalization QueryNormalization) (searchQuery, error) {
+	upstream := normalization.upstream()
+
+	text, err := upstream.NormalizeQuery(text)
+	if err != nil {
+		return searchQuery{}, err
+	}
+	filters = upstream.normalizeFilters(filters)
+
+	query, err := composeQuery(text, filters, searchTypes)
+	if err != nil {
+		return searchQuery{}, err
+	}
+
+	key, err := composeQuery(normalization.normalize(text), normalization.normalizeFilters(filters), searchTypes)
+	if err != nil {
+		return searchQuery{}, err
+	}
+
+	return searchQuery{text: query, key: key}, nil
+}
+
+// composeQuery validates and appends the filters to the free-text query in a
+// fixed order, so that equivalent searches share the same query.
+func composeQuery(text string, filters SearchFilters, searchTypes []string) (string, error) {
+	parts := []string{}
+	if text = strings.TrimSpace(text); text != "" {
+		parts = append(parts, text)
+	}
+
+	year, err := normalizeYear(filters.Year)
+	if err != nil {
+		return "", err
+	}
+
+	isrc := strings.ToUpper(strings.TrimSpace(filters.ISRC))
+	if isrc != "" && !isrcPattern.MatchString(isrc) {
+		return "", fmt.Errorf("%w: invalid isrc %q", ErrInvalidFilter, filters.ISRC)
+	}
+
+	upc := strings.TrimSpace(filters.UPC)
+	if upc != "" && !upcPattern.MatchString(upc) {
+		return "", fmt.Errorf("%w: invalid upc %q", ErrInvalidFilter, filters.UPC)
+	}
+
+	tag := strings.ToLower(strings.TrimSpace(filters.Tag))
+	if tag != "" && tag != "new" && tag != "hipster" {
+		return "", fmt.Errorf("%w: tag must be new or hipster", ErrInvalidFilter)
+	}
+
+	for _, filter := range []struct {
+		name  string
+		value string
+	}{
+		{"artist", filters.Artist},
+		{"album", filters.Album},
+		{"track", filters.Track},
+		{"year", year},
+		{"genre", filters.Genre},
+		{"isrc", isrc},
+		{"upc", upc},
+		{"tag", tag},
+	} {
+		value := escapeFilterValue(filter.value)
+		if value == "" {
+			continue
+		}
+
+		for _, searchType := range searchTypes {
+			if !slices.Contains(filterTypes[filter.name], searchType) {
+				return "", fmt.Errorf("%w: %s cannot be used to search for %s", ErrInvalidFilter, filter.name, searchType)
+			}
+		}
+
+		parts = append(parts, filter.name+":"+value)
+	}
+
+	if len(parts) == 0 {
+		return "", fmt.Errorf("%w: query is required", ErrInvalidQuery)
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// escapeFilterValue quotes values with spaces so that Spotify applies the
+// filter to all of the words. Spotify has no way to escape quotes, so they
+// are dropped.
+func escapeFilterValue(value string) string {
+	value = strings.ReplaceAll(value, `"`, "")
+	value = strings.Join(strings.Fields(value), " ")
+
+	if strings.Contains(value, " ") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+func normalizeYear(year string) (string, error) {
+	year = strings.TrimSpace(year)
+	if year == "" {
+		return "", nil
+	}
+
+	matches := yearPattern.FindStringSubmatch(year)
+	if matches == nil {
+		return "", fmt.Errorf("%w: year must be YYYY or YYYY-YYYY", ErrInvalidFilter)
+	}
+
+	if matches[2] != "" {
+		from, _ := strconv.Atoi(matches[1])
+		to, _ := strconv.Atoi(matches[2])
+		if from > to {
+			return "", fmt.Errorf("%w: year range %s is reversed", ErrInvalidFilter, year)
+		}
+		if from == to {
+			return matches[1], nil
+		}
+	}
+
+	return year, nil
+}
@@ -0,0 +1,168 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// shortLinkHosts serve short links that redirect to open.spotify.com.
+var shortLinkHosts = []string{"spotify.link", "spotify.app.link"}
+
+// Localized links are prefixed with e.g. /intl-fr/ or /intl-pt-br/.
+var intlPattern = regexp.MustCompile(`^intl-[a-z]{2,3}(-[a-z0-9]+)?$`)
+
+// Resolve returns the artist, album or track of a Spotify link or URI, such
+// as https://open.spotify.com/track/...?si=..., spotify:album:... or a
+// spotify.link short link.
+func (s SpotifySearchService) Resolve(ctx context.Context, link string, market string) (domain.Item, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.Resolve")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("link", link))
+
+	itemType, id, err := s.parseLink(ctx, link, true)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("type", itemType),
+		attribute.String("id", id),
+	)
+
+	if !slices.Contains(IDTypes, itemType) {
+		return nil, fmt.Errorf("%w: %s links are not supported", ErrInvalidLink, itemType)
+	}
+
+	return s.GetByID(ctx, itemType, id, market)
+}
+
+// parseLink returns the type and ID of a link. Short links are only followed
+// when allowed, so that a short link can't redirect to another one.
+func (s SpotifySearchService) parseLink(ctx context.Context, link string, followShortLinks bool) (string, string, error) {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return "", "", fmt.Errorf("%w: url is required", ErrInvalidLink)
+	}
+
+	if strings.HasPrefix(link, "spotify:") {
+		return parseURI(link)
+	}
+
+	// Links are often pasted without their scheme
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %s", ErrInvalidLink, err.Error())
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return "", "", fmt.Errorf("%w: unsupported scheme %q", ErrInvalidLink, parsed.Scheme)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	switch {
+	case host == "open.spotify.com" || host == "play.spotify.com":
+		return parsePath(parsed.Path)
+	case host == "embed.spotify.com":
+		// Legacy embeds: https://embed.spotify.com/?uri=spotify:track:...
+		return parseURI(parsed.Query().Get("uri"))
+	case slices.Contains(shortLinkHosts, host):
+		if !followShortLinks {
+			return "", "", fmt.Errorf("%w: too many redirects", ErrInvalidLink)
+		}
+
+		target, err := s.resolveShortLink(ctx, parsed)
+		if err != nil {
+			return "", "", err
+		}
+		return s.parseLink(ctx, target, false)
+	}
+
+	return "", "", fmt.Errorf("%w: unsupported host %q", ErrInvalidLink, host)
+}
+
+// parsePath parses the path of an open.spotify.com link, such as
+// /intl-fr/track/:id or /embed/album/:id.
+func parsePath(path string) (string, string, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(segments) > 0 && intlPattern.MatchString(segments[0]) {
+		segments = segments[1:]
+	}
+	if len(segments) > 0 && (segments[0] == "embed" || segments[0] == "embed-podcast") {
+		segments = segments[1:]
+	}
+
+	if len(segments) != 2 {
+		return "", "", fmt.Errorf("%w: unsupported path %q", ErrInvalidLink, path)
+	}
+
+	return parseTypeAndID(segments[0], segments[1])
+}
+
+// parseURI parses a spotify:type:id URI.
+func parseURI(uri string) (string, string, error) {
+	parts := strings.Split(uri, ":")
+	if len(parts) != 3 || parts[0] != "spotify" {
+		return "", "", fmt.Errorf("%w: unsupported uri %q", ErrInvalidLink, uri)
+	}
+
+	return parseTypeAndID(parts[1], parts[2])
+}
+
+func parseTypeAndID(itemType string, id string) (string, string, error) {
+	if !slices.Contains(SearchTypes, itemType) {
+		return "", "", fmt.Errorf("%w: unknown type %q", ErrInvalidLink, itemType)
+	}
+	if !idPattern.MatchString(id) {
+		return "", "", fmt.Errorf("%w: invalid id %q", ErrInvalidLink, id)
+	}
+	return itemType, id, nil
+}
+
+// resolveShortLink returns the target of a short link, which is cached.
+func (s SpotifySearchService) resolveShortLink(ctx context.Context, link *url.URL) (string, error) {
+	if s.config.ShortLinkResolver == nil {
+		return "", fmt.Errorf("%w: short links are not supported", ErrInvalidLink)
+	}
+
+	code := strings.Trim(link.Path, "/")
+	if code == "" || strings.Contains(code, "/") {
+		return "", fmt.Errorf("%w: invalid short link", ErrInvalidLink)
+	}
+
+	key := s.shortLinkCacheKey(link.Hostname(), code)
+	if target, err := s.cache.Get(ctx, key); err == nil && target != "" {
+		return target, nil
+	}
+
+	target, err := s.config.ShortLinkResolver.Resolve(ctx, "https://"+link.Hostname()+"/"+code)
+	if err != nil {
+		if errors.Is(err, ErrInvalidLink) {
+			return "", err
+		}
+		return "", fmt.Errorf("%w: %s", ErrSpotifyClient, err.Error())
+	}
+
+	if err := s.cache.Set(ctx, key, []byte(target), s.ttl(s.resourceTTL("short-link"))); err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+
+	return target, nil
+}
+
+func (s SpotifySearchService) shortLinkCacheKey(host string, code string) string {
+	return s.keyspace.key(fmt.Sprintf("link:%s:%s", strings.ToLower(host), code))
+}
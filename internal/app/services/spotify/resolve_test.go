@@ -0,0 +1,215 @@
+package spotify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifySearchService_Resolve(t *testing.T) {
+	tests := []struct {
+		name         string
+		link         string
+		setup        func(cache *mocks.MockCache, resolver *mocks.MockShortLinkResolver)
+		expectedType string
+		expectedID   string
+		expectedErr  error
+	}{
+		{
+			name:         "share link",
+			link:         "https://open.spotify.com/track/" + fancyID + "?si=0123456789abcdef",
+			expectedType: "track",
+			expectedID:   fancyID,
+		},
+		{
+			name:         "localized link",
+			link:         "https://open.spotify.com/intl-fr/artist/" + twiceID,
+			expectedType: "artist",
+			expectedID:   twiceID,
+		},
+		{
+			name:         "localized link with region",
+			link:         "https://open.spotify.com/intl-pt-br/album/" + fancyID,
+			expectedType: "album",
+			expectedID:   fancyID,
+		},
+		{
+			name:         "without scheme",
+			link:         "open.spotify.com/artist/" + twiceID,
+			expectedType: "artist",
+			expectedID:   twiceID,
+		},
+		{
+			name:         "uri",
+			link:         "spotify:album:" + fancyID,
+			expectedType: "album",
+			expectedID:   fancyID,
+		},
+		{
+			name:         "embed",
+			link:         "https://open.spotify.com/embed/track/" + fancyID + "?utm_source=generator",
+			expectedType: "track",
+			expectedID:   fancyID,
+		},
+		{
+			name:         "legacy embed",
+			link:         "https://embed.spotify.com/?uri=spotify:track:" + fancyID,
+			expectedType: "track",
+			expectedID:   fancyID,
+		},
+		{
+			name: "short link",
+			link: "https://spotify.link/AbCdEf",
+			setup: func(cache *mocks.MockCache, resolver *mocks.MockShortLinkResolver) {
+				cache.On("Get", mock.Anything, "spotify:v2:g0:link:spotify.link:AbCdEf").
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				resolver.On("Resolve", mock.Anything, "https://spotify.link/AbCdEf").
+					Return("https://open.spotify.com/artist/"+twiceID+"?si=abc", nil).
+					Once()
+				cache.On("Set", mock.Anything, "spotify:v2:g0:link:spotify.link:AbCdEf", []byte("https://open.spotify.com/artist/"+twiceID+"?si=abc"), mock.Anything).
+					Return(nil).
+					Once()
+			},
+			expectedType: "artist",
+			expectedID:   twiceID,
+		},
+		{
+			name: "cached short link",
+			link: "spotify.link/AbCdEf",
+			setup: func(cache *mocks.MockCache, resolver *mocks.MockShortLinkResolver) {
+				cache.On("Get", mock.Anything, "spotify:v2:g0:link:spotify.link:AbCdEf").
+					Return("https://open.spotify.com/track/"+fancyID, nil).
+					Once()
+			},
+			expectedType: "track",
+			expectedID:   fancyID,
+		},
+		{
+			name: "unknown short link",
+			link: "https://spotify.link/nope",
+			setup: func(cache *mocks.MockCache, resolver *mocks.MockShortLinkResolver) {
+				cache.On("Get", mock.Anything, "spotify:v2:g0:link:spotify.link:nope").
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				resolver.On("Resolve", mock.Anything, "https://spotify.link/nope").
+					Return("", spotify.ErrInvalidLink).
+					Once()
+			},
+			expectedErr: spotify.ErrInvalidLink,
+		},
+		{
+			name: "short link resolver error",
+			link: "https://spotify.link/AbCdEf",
+			setup: func(cache *mocks.MockCache, resolver *mocks.MockShortLinkResolver) {
+				cache.On("Get", mock.Anything, "spotify:v2:g0:link:spotify.link:AbCdEf").
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				resolver.On("Resolve", mock.Anything, "https://spotify.link/AbCdEf").
+					Return("", errors.New("timeout")).
+					Once()
+			},
+			expectedErr: spotify.ErrSpotifyClient,
+		},
+		{
+			name: "short link to a short link",
+			link: "https://spotify.link/AbCdEf",
+			setup: func(cache *mocks.MockCache, resolver *mocks.MockShortLinkResolver) {
+				cache.On("Get", mock.Anything, "spotify:v2:g0:link:spotify.link:AbCdEf").
+					Return("https://spotify.link/AbCdEf", nil).
+					Once()
+			},
+			expectedErr: spotify.ErrInvalidLink,
+		},
+		{
+			name:        "unsupported type",
+			link:        "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M",
+			expectedErr: spotify.ErrInvalidLink,
+		},
+		{
+			name:        "unknown type",
+			link:        "spotify:concert:" + twiceID,
+			expectedErr: spotify.ErrInvalidLink,
+		},
+		{
+			name:        "invalid id",
+			link:        "https://open.spotify.com/track/fancy",
+			expectedErr: spotify.ErrInvalidLink,
+		},
+		{
+			name:        "other host",
+			link:        "https://music.apple.com/track/" + fancyID,
+			expectedErr: spotify.ErrInvalidLink,
+		},
+		{
+			name:        "other scheme",
+			link:        "ftp://open.spotify.com/track/" + fancyID,
+			expectedErr: spotify.ErrInvalidLink,
+		},
+		{
+			name:        "legacy user playlist uri",
+			link:        "spotify:user:twice:playlist:37i9dQZF1DXcBWIGoYBM5M",
+			expectedErr: spotify.ErrInvalidLink,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedSpotifyClient := &mocks.MockSpotifyClient{}
+			mockedCache := &mocks.MockCache{}
+			mockedResolver := &mocks.MockShortLinkResolver{}
+			t.Cleanup(func() {
+				mockedCache.AssertExpectations(t)
+				mockedSpotifyClient.AssertExpectations(t)
+				mockedResolver.AssertExpectations(t)
+			})
+
+			s := spotify.New(
+				otel.Tracer("test"),
+				mockedSpotifyClient,
+				mockedCache,
+				spotify.Config{ShortLinkResolver: mockedResolver},
+			)
+
+			if tt.setup != nil {
+				tt.setup(mockedCache, mockedResolver)
+			}
+
+			if tt.expectedErr == nil {
+				market := ""
+				if tt.expectedType != "artist" {
+					market = "FR"
+				}
+
+				mockedCache.On("MGet", mock.Anything, []string{"spotify:v2:g0:id:" + tt.expectedType + ":" + market + ":" + tt.expectedID}).
+					Return([]string{`{"type": "` + tt.expectedType + `", "id": "` + tt.expectedID + `"}`}, nil).
+					Once()
+			}
+
+			item, err := s.Resolve(context.Background(), tt.link, "FR")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.NotErrorIs(t, err, spotify.ErrInvalidQueryType)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedType, item.ObjectType())
+			assert.Equal(t, tt.expectedID, item.ObjectID())
+		})
+	}
+
+	t.Run("short links disabled", func(t *testing.T) {
+		s := spotify.New(otel.Tracer("test"), &mocks.MockSpotifyClient{}, &mocks.MockCache{}, spotify.Config{})
+
+		_, err := s.Resolve(context.Background(), "https://spotify.link/AbCdEf", "")
+		assert.ErrorIs(t, err, spotify.ErrInvalidLink)
+	})
+}
@@ -4,57 +4,305 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/url"
-	"time"
+	"slices"
+	"strings"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func (s SpotifySearchService) Search(ctx context.Context, query string, searchType string) (any, error) {
+// SearchTypes are the Spotify object types that can be searched for.
+var SearchTypes = []string{
+	"artist",
+	"album",
+	"track",
+	"playlist",
+	"show",
+	"episode",
+	"audiobook",
+}
+
+// Search returns the result that best matches the query, picked among the
+// first results. Paging options are ignored.
+func (s SpotifySearchService) Search(ctx context.Context, query string, searchType string, opts SearchOptions, matchOpts MatchOptions) (*Match, error) {
 	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.Search")
 	defer span.End()
 
-	// Check if the query type is valid
-	switch searchType {
-	case "artist", "album", "track":
-	default:
-		return nil, fmt.Errorf("%w: %s", ErrInvalidQueryType, searchType)
+	matches, err := s.bestMatches(ctx, query, []string{searchType}, opts, matchOpts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if the result is cached
-	key := "spotify:" + searchType + ":" + query
-	val, err := s.cache.Get(ctx, key)
-	if err == nil && val != "" {
-		var cachedResult any
-		err = json.Unmarshal([]byte(val), &cachedResult)
-		if err == nil {
-			return cachedResult, nil
-		}
+	match := matches[searchType]
+	if match == nil {
+		return nil, ErrNoResultsFound
 	}
 
-	// The Spotify SDK will re-encode it, so we need to decode it first
-	// TODO move?
-	decodedQuery, err := url.QueryUnescape(query)
+	span.SetAttributes(attribute.Float64("confidence", match.Confidence))
+
+	return match, nil
+}
+
+// BestMatches returns the best match for each of the given types, or nil for
+// the types without a good enough match.
+func (s SpotifySearchService) BestMatches(ctx context.Context, query string, searchTypes []string, opts SearchOptions, matchOpts MatchOptions) (map[string]*Match, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.BestMatches")
+	defer span.End()
+
+	searchTypes = uniqueSearchTypes(searchTypes)
+
+	span.SetAttributes(attribute.StringSlice("types", searchTypes))
+
+	return s.bestMatches(ctx, query, searchTypes, opts, matchOpts)
+}
+
+func (s SpotifySearchService) bestMatches(ctx context.Context, query string, searchTypes []string, opts SearchOptions, matchOpts MatchOptions) (map[string]*Match, error) {
+	if err := matchOpts.validate(); err != nil {
+		return nil, err
+	}
+
+	opts.Limit, opts.Offset = matchCandidates, 0
+
+	opts, err := s.searchOptions(opts)
 	if err != nil {
-		return nil, err // TODO err
+		return nil, err
 	}
 
-	// Search for the query
-	result, err := s.spotifyClient.Search(ctx, decodedQuery, searchType)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("market", opts.Market))
+
+	pages, err := s.search(ctx, query, searchTypes, opts)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", ErrSpotifyClient, err.Error())
+		return nil, err
 	}
-	if result == nil {
-		return nil, ErrNoResultsFound
+
+	matches := make(map[string]*Match, len(pages))
+	for searchType, page := range pages {
+		target := matchTarget(query, opts.Filters, searchType)
+		matches[searchType] = bestMatch(page.Items, target, matchOpts)
+	}
+
+	return matches, nil
+}
+
+// SearchPage returns a paginated list of results for the query.
+func (s SpotifySearchService) SearchPage(ctx context.Context, query string, searchType string, opts SearchOptions) (*SearchPage, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.SearchPage")
+	defer span.End()
+
+	opts, err := s.searchOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("limit", opts.Limit),
+		attribute.Int("offset", opts.Offset),
+		attribute.String("market", opts.Market),
+	)
+
+	pages, err := s.search(ctx, query, []string{searchType}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return pages[searchType], nil
+}
+
+// MultiSearch returns a page of results for each of the given types, keyed
+// by type. Types that aren't cached yet are fetched in a single upstream
+// call per normalized query, and each type is then cached on its own so
+// that single-type searches with the same parameters reuse it.
+func (s SpotifySearchService) MultiSearch(ctx context.Context, query string, searchTypes []string, opts SearchOptions) (map[string]*SearchPage, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.MultiSearch")
+	defer span.End()
+
+	opts, err := s.searchOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	searchTypes = uniqueSearchTypes(searchTypes)
+
+	span.SetAttributes(
+		attribute.StringSlice("types", searchTypes),
+		attribute.Int("limit", opts.Limit),
+		attribute.Int("offset", opts.Offset),
+		attribute.String("market", opts.Market),
+	)
+
+	return s.search(ctx, query, searchTypes, opts)
+}
+
+func (s SpotifySearchService) search(ctx context.Context, query string, searchTypes []string, opts SearchOptions) (map[string]*SearchPage, error) {
+	// Check if the query types are valid
+	for _, searchType := range searchTypes {
+		if !slices.Contains(SearchTypes, searchType) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidQueryType, searchType)
+		}
+	}
+
+	// Each type has its own normalization, so the query may differ by type
+	queries := make(map[string]searchQuery, len(searchTypes))
+	for _, searchType := range searchTypes {
+		typeQuery, err := buildQuery(query, opts.Filters, []string{searchType}, s.normalization(searchType))
+		if err != nil {
+			return nil, err
+		}
+		queries[searchType] = typeQuery
+	}
+
+	// The filters are part of the query from now on
+	opts.Filters = SearchFilters{}
+
+	if len(searchTypes) > 0 {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("query", queries[searchTypes[0]].text))
+	}
+
+	pages := make(map[string]*SearchPage, len(searchTypes))
+
+	// Check which results are cached, grouping the missing types by the
+	// query sent to Spotify
+	var missingTexts []string
+	missingTypes := map[string][]string{}
+	for _, searchType := range searchTypes {
+		if page, ok := s.cachedPage(ctx, searchType, queries[searchType], opts); ok {
+			pages[searchType] = page
+			continue
+		}
+
+		text := queries[searchType].text
+		if _, ok := missingTypes[text]; !ok {
+			missingTexts = append(missingTexts, text)
+		}
+		missingTypes[text] = append(missingTypes[text], searchType)
+	}
+
+	// Search for each query, which is a single upstream call unless the
+	// types are normalized differently
+	for _, text := range missingTexts {
+		fetched, err := s.searchMissing(ctx, missingTypes[text], queries, opts)
+		if err != nil {
+			return nil, err
+		}
+		for searchType, page := range fetched {
+			pages[searchType] = page
+		}
+	}
+
+	return pages, nil
+}
+
+// cachedPage returns the cached search result of a type, if any. Stale
+// results are searched again in the background.
+func (s SpotifySearchService) cachedPage(ctx context.Context, searchType string, query searchQuery, opts SearchOptions) (*SearchPage, bool) {
+	key := s.searchCacheKey(searchType, query, opts)
+
+	val, err := s.cache.Get(ctx, key)
+	if err != nil || val == "" {
+		return nil, false
+	}
+	if isNegative(ctx, val) {
+		return NewSearchPage(nil, 0, opts), true
+	}
+
+	payload, stale := decodeEntry(val)
+	var cachedResult SearchPage
+	if err := json.Unmarshal(payload, &cachedResult); err != nil {
+		return nil, false
+	}
+	if stale {
+		s.refreshPage(ctx, key, searchType, query, opts)
+	}
+	return &cachedResult, true
+}
+
+// searchMissing searches for the types, whose queries are sent to Spotify as
+// the same text, and caches their results. Concurrent identical searches
+// share the same upstream call.
+func (s SpotifySearchService) searchMissing(ctx context.Context, searchTypes []string, queries map[string]searchQuery, opts SearchOptions) (map[string]*SearchPage, error) {
+	keys := make([]string, 0, len(searchTypes))
+	for _, searchType := range searchTypes {
+		keys = append(keys, s.searchCacheKey(searchType, queries[searchType], opts))
+	}
+
+	cached := func(ctx context.Context) (map[string]*SearchPage, error) {
+		pages := make(map[string]*SearchPage, len(searchTypes))
+		for _, searchType := range searchTypes {
+			page, ok := s.cachedPage(ctx, searchType, queries[searchType], opts)
+			if !ok {
+				return nil, errNotCached
+			}
+			pages[searchType] = page
+		}
+		return pages, nil
+	}
+
+	return coalesce(ctx, s, strings.Join(keys, "|"), cached, func(ctx context.Context) (map[string]*SearchPage, error) {
+		results, err := s.spotifyClient.Search(ctx, queries[searchTypes[0]].text, searchTypes, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrSpotifyClient, err.Error())
+		}
+
+		pages := make(map[string]*SearchPage, len(searchTypes))
+		for _, searchType := range searchTypes {
+			page, err := s.cachePage(ctx, searchType, queries[searchType], opts, results[searchType])
+			if err != nil {
+				return nil, err
+			}
+			pages[searchType] = page
+		}
+		return pages, nil
+	})
+}
+
+// cachePage caches the result of a search, or a not-found outcome if it is
+// empty, and returns the page to serve.
+func (s SpotifySearchService) cachePage(ctx context.Context, searchType string, query searchQuery, opts SearchOptions, result *SearchPage) (*SearchPage, error) {
+	key := s.searchCacheKey(searchType, query, opts)
+
+	if result == nil || len(result.Items) == 0 {
+		if err := s.cache.Set(ctx, key, []byte(domain.NotFoundCacheValue), s.ttl(s.negativeTTL())); err != nil {
+			trace.SpanFromContext(ctx).RecordError(err)
+		}
+		return NewSearchPage(nil, 0, opts), nil
 	}
 
-	// Cache the result
 	marshaledResult, err := json.Marshal(result)
 	if err != nil {
 		return nil, err // TODO err
 	}
-	err = s.cache.Set(ctx, key, marshaledResult, time.Hour*24)
-	if err != nil {
-		span.RecordError(err)
+	ttl := s.ttl(s.searchTTL(searchType))
+	if err := s.cache.Set(ctx, key, s.encodeEntry(marshaledResult, ttl), s.keyTTL(ttl)); err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
 	}
 
 	return result, nil
 }
+
+// refreshPage serves a stale search result, and searches it again in the
+// background.
+func (s SpotifySearchService) refreshPage(ctx context.Context, key string, searchType string, query searchQuery, opts SearchOptions) {
+	s.serveStale(ctx, key, func(ctx context.Context) error {
+		results, err := s.spotifyClient.Search(ctx, query.text, []string{searchType}, opts)
+		if err != nil {
+			return err
+		}
+		_, err = s.cachePage(ctx, searchType, query, opts, results[searchType])
+		return err
+	})
+}
+
+func uniqueSearchTypes(searchTypes []string) []string {
+	unique := make([]string, 0, len(searchTypes))
+	for _, searchType := range searchTypes {
+		if !slices.Contains(unique, searchType) {
+			unique = append(unique, searchType)
+		}
+	}
+	return unique
+}
+
+func (s SpotifySearchService) searchCacheKey(searchType string, query searchQuery, opts SearchOptions) string {
+	return s.keyspace.key(fmt.Sprintf("%s:%s:%s:%d:%d:%s", searchType, opts.Market, opts.Locale, opts.Limit, opts.Offset, query.key))
+}
@@ -2,18 +2,30 @@ package spotify_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
 	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
 	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
-	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/redis"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 )
 
+func newItem(t *testing.T, itemType string, name string) domain.Item {
+	item, err := domain.UnmarshalItem([]byte(fmt.Sprintf(`{"type": %q, "name": %q}`, itemType, name)))
+	require.NoError(t, err)
+	return item
+}
+
 func TestSpotifySearchService_Search(t *testing.T) {
 	mockedSpotifyClient := &mocks.MockSpotifyClient{}
 	mockedCache := &mocks.MockCache{}
@@ -22,96 +34,728 @@ func TestSpotifySearchService_Search(t *testing.T) {
 		otel.Tracer("test"),
 		mockedSpotifyClient,
 		mockedCache,
+		spotify.Config{},
 	)
 
 	t.Run("invalid query type", func(t *testing.T) {
-		_, err := s.Search(context.Background(), "test", "invalid")
+		_, err := s.Search(context.Background(), "test", "invalid", spotify.SearchOptions{}, spotify.MatchOptions{})
 		assert.ErrorIs(t, err, spotify.ErrInvalidQueryType)
 	})
 
+	t.Run("supported query types", func(t *testing.T) {
+		for _, searchType := range spotify.SearchTypes {
+			key := "spotify:v2:g0:" + searchType + ":::10:0:twice"
+
+			mockedCache.On("Get", mock.Anything, key).
+				Return("", spotify.ErrCacheMiss).
+				Once()
+			mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{searchType}, spotify.SearchOptions{Limit: 10}).
+				Return(map[string]*spotify.SearchPage{
+					searchType: spotify.NewSearchPage([]domain.Item{newItem(t, searchType, "TWICE")}, 1, spotify.SearchOptions{Limit: 10}),
+				}, nil).
+				Once()
+			mockedCache.On("Set", mock.Anything, key, mock.Anything, time.Hour*24).
+				Return(nil).
+				Once()
+
+			result, err := s.Search(context.Background(), "TWICE", searchType, spotify.SearchOptions{}, spotify.MatchOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, searchType, result.Item.ObjectType())
+		}
+	})
+
 	t.Run("no results found", func(t *testing.T) {
 		mockedCache.On("Get",
 			mock.Anything,
-			"spotify:artist:TWICE",
+			"spotify:v2:g0:artist:::10:0:twice",
 		).
-			Return("", redis.ErrCacheMiss).
+			Return("", spotify.ErrCacheMiss).
 			Once()
 		mockedSpotifyClient.On("Search",
-			mock.Anything, "TWICE", "artist",
+			mock.Anything, "TWICE", []string{"artist"}, spotify.SearchOptions{Limit: 10},
 		).
 			Return(nil, nil).
 			Once()
+		mockedCache.On("Set",
+			mock.Anything,
+			"spotify:v2:g0:artist:::10:0:twice",
+			[]byte(domain.NotFoundCacheValue),
+			spotify.DefaultNegativeCacheTTL,
+		).
+			Return(nil).
+			Once()
 
-		_, err := s.Search(context.Background(), "TWICE", "artist")
+		_, err := s.Search(context.Background(), "TWICE", "artist", spotify.SearchOptions{}, spotify.MatchOptions{})
 		assert.ErrorIs(t, err, spotify.ErrNoResultsFound)
 	})
 
+	t.Run("cached no results", func(t *testing.T) {
+		mockedCache.On("Get",
+			mock.Anything,
+			"spotify:v2:g0:artist:::10:0:twice",
+		).
+			Return(domain.NotFoundCacheValue, nil).
+			Once()
+
+		_, err := s.Search(context.Background(), "TWICE", "artist", spotify.SearchOptions{}, spotify.MatchOptions{})
+		assert.ErrorIs(t, err, spotify.ErrNoResultsFound)
+	})
+
+	t.Run("cached no results bypassed", func(t *testing.T) {
+		mockedCache.On("Get",
+			mock.Anything,
+			"spotify:v2:g0:artist:::10:0:twice",
+		).
+			Return(domain.NotFoundCacheValue, nil).
+			Once()
+		mockedSpotifyClient.On("Search",
+			mock.Anything, "TWICE", []string{"artist"}, spotify.SearchOptions{Limit: 10},
+		).
+			Return(map[string]*spotify.SearchPage{
+				"artist": spotify.NewSearchPage([]domain.Item{newItem(t, "artist", "TWICE")}, 1, spotify.SearchOptions{Limit: 10}),
+			}, nil).
+			Once()
+		mockedCache.On("Set",
+			mock.Anything,
+			"spotify:v2:g0:artist:::10:0:twice",
+			mock.Anything,
+			time.Hour*24,
+		).
+			Return(nil).
+			Once()
+
+		ctx := spotify.WithoutNegativeCache(context.Background())
+		match, err := s.Search(ctx, "TWICE", "artist", spotify.SearchOptions{}, spotify.MatchOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "TWICE", match.Item.ObjectName())
+	})
+
 	t.Run("spotify client error", func(t *testing.T) {
 		mockedCache.On("Get",
 			mock.Anything,
-			"spotify:artist:TWICE",
+			"spotify:v2:g0:artist:::10:0:twice",
 		).
-			Return("", redis.ErrCacheMiss).
+			Return("", spotify.ErrCacheMiss).
 			Once()
 		mockedSpotifyClient.On("Search",
-			mock.Anything, "TWICE", "artist",
+			mock.Anything, "TWICE", []string{"artist"}, spotify.SearchOptions{Limit: 10},
 		).
 			Return(nil, spotify.ErrSpotifyClient).
 			Once()
 
-		_, err := s.Search(context.Background(), "TWICE", "artist")
+		_, err := s.Search(context.Background(), "TWICE", "artist", spotify.SearchOptions{}, spotify.MatchOptions{})
 		assert.ErrorIs(t, err, spotify.ErrSpotifyClient)
 	})
 
 	t.Run("cache miss", func(t *testing.T) {
 		mockedCache.On("Get",
 			mock.Anything,
-			"spotify:artist:TWICE",
+			"spotify:v2:g0:artist:::10:0:twice",
 		).
-			Return("", redis.ErrCacheMiss).
+			Return("", spotify.ErrCacheMiss).
 			Once()
 		mockedSpotifyClient.On("Search",
-			mock.Anything, "TWICE", "artist",
+			mock.Anything, "TWICE", []string{"artist"}, spotify.SearchOptions{Limit: 10},
 		).
-			Return("data", nil).
+			Return(map[string]*spotify.SearchPage{
+				"artist": spotify.NewSearchPage([]domain.Item{newItem(t, "artist", "TWICE")}, 1, spotify.SearchOptions{Limit: 10}),
+			}, nil).
 			Once()
 		mockedCache.On("Set",
 			mock.Anything,
-			"spotify:artist:TWICE",
+			"spotify:v2:g0:artist:::10:0:twice",
 			mock.Anything,
 			time.Hour*24,
 		).
 			Return(nil).
 			Once()
 
-		_, err := s.Search(context.Background(), "TWICE", "artist")
+		_, err := s.Search(context.Background(), "TWICE", "artist", spotify.SearchOptions{}, spotify.MatchOptions{})
 		assert.NoError(t, err)
 	})
 
 	t.Run("cache hit", func(t *testing.T) {
 		mockedCache.On("Get",
 			mock.Anything,
-			"spotify:artist:TWICE",
+			"spotify:v2:g0:artist:::10:0:twice",
 		).
-			Return(`{"data": "TODO"}`, nil).
+			Return(`{"items": [{"type": "artist", "name": "TWICE"}], "total": 1}`, nil).
 			Once()
 
-		_, err := s.Search(context.Background(), "TWICE", "artist")
+		_, err := s.Search(context.Background(), "TWICE", "artist", spotify.SearchOptions{}, spotify.MatchOptions{})
 		assert.NoError(t, err)
 	})
 
 	t.Run("cache set error", func(t *testing.T) {
-		mockedCache.On("Get", mock.Anything, "spotify:artist:TWICE").
-			Return("", redis.ErrCacheMiss).
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist:::10:0:twice").
+			Return("", spotify.ErrCacheMiss).
 			Once()
-		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", "artist").
-			Return("data", nil).
+		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"artist"}, spotify.SearchOptions{Limit: 10}).
+			Return(map[string]*spotify.SearchPage{
+				"artist": spotify.NewSearchPage([]domain.Item{newItem(t, "artist", "TWICE")}, 1, spotify.SearchOptions{Limit: 10}),
+			}, nil).
 			Once()
-		mockedCache.On("Set", mock.Anything, "spotify:artist:TWICE", mock.Anything, time.Hour*24).
+		mockedCache.On("Set", mock.Anything, "spotify:v2:g0:artist:::10:0:twice", mock.Anything, time.Hour*24).
 			Return(errors.New("TODO")).
 			Once()
 
-		_, err := s.Search(context.Background(), "TWICE", "artist")
+		_, err := s.Search(context.Background(), "TWICE", "artist", spotify.SearchOptions{}, spotify.MatchOptions{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestSpotifySearchService_SearchPage(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+
+	s := spotify.New(
+		otel.Tracer("test"),
+		mockedSpotifyClient,
+		mockedCache,
+		spotify.Config{},
+	)
+
+	t.Run("invalid pagination", func(t *testing.T) {
+		for _, opts := range []spotify.SearchOptions{
+			{Limit: -1},
+			{Limit: spotify.MaxSearchLimit + 1},
+			{Limit: 10, Offset: -1},
+			{Limit: 10, Offset: spotify.MaxSearchOffset},
+		} {
+			_, err := s.SearchPage(context.Background(), "TWICE", "artist", opts)
+			assert.ErrorIs(t, err, spotify.ErrInvalidPagination)
+		}
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		opts := spotify.SearchOptions{Limit: spotify.DefaultSearchLimit}
+
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist:::20:0:twice").
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"artist"}, opts).
+			Return(map[string]*spotify.SearchPage{
+				"artist": spotify.NewSearchPage([]domain.Item{newItem(t, "artist", "TWICE"), newItem(t, "artist", "TWICE tribute")}, 42, opts),
+			}, nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, "spotify:v2:g0:artist:::20:0:twice", mock.Anything, time.Hour*24).
+			Return(nil).
+			Once()
+
+		page, err := s.SearchPage(context.Background(), "TWICE", "artist", spotify.SearchOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 42, page.Total)
+		assert.Equal(t, 20, *page.Next)
+		assert.Nil(t, page.Previous)
+	})
+
+	t.Run("paging parameters in cache key", func(t *testing.T) {
+		opts := spotify.SearchOptions{Limit: 10, Offset: 30}
+
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist:::10:30:twice").
+			Return(`{"items": [{"type": "artist", "name": "TWICE"}], "total": 31, "limit": 10, "offset": 30, "next": null, "previous": 20}`, nil).
+			Once()
+
+		page, err := s.SearchPage(context.Background(), "TWICE", "artist", opts)
+		assert.NoError(t, err)
+		assert.Nil(t, page.Next)
+		assert.Equal(t, 20, *page.Previous)
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		opts := spotify.SearchOptions{Limit: 5}
+
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist:::5:0:nothing").
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "nothing", []string{"artist"}, opts).
+			Return(map[string]*spotify.SearchPage{
+				"artist": spotify.NewSearchPage(nil, 0, opts),
+			}, nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, "spotify:v2:g0:artist:::5:0:nothing", []byte(domain.NotFoundCacheValue), spotify.DefaultNegativeCacheTTL).
+			Return(nil).
+			Once()
+
+		page, err := s.SearchPage(context.Background(), "nothing", "artist", opts)
+		assert.NoError(t, err)
+		assert.Empty(t, page.Items)
+		assert.Zero(t, page.Total)
+	})
+
+	mockedCache.AssertExpectations(t)
+	mockedSpotifyClient.AssertExpectations(t)
+}
+
+func TestSpotifySearchService_MultiSearch(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+
+	s := spotify.New(
+		otel.Tracer("test"),
+		mockedSpotifyClient,
+		mockedCache,
+		spotify.Config{},
+	)
+
+	t.Run("invalid query type", func(t *testing.T) {
+		_, err := s.MultiSearch(context.Background(), "TWICE", []string{"artist", "invalid"}, spotify.SearchOptions{})
+		assert.ErrorIs(t, err, spotify.ErrInvalidQueryType)
+	})
+
+	t.Run("only missing types are fetched and cached", func(t *testing.T) {
+		opts := spotify.SearchOptions{Limit: spotify.DefaultSearchLimit}
+
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist:::20:0:twice").
+			Return(`{"items": [{"type": "artist", "name": "cached artist"}], "total": 1}`, nil).
+			Once()
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:album:::20:0:twice").
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:track:::20:0:twice").
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"album", "track"}, opts).
+			Return(map[string]*spotify.SearchPage{
+				"album": spotify.NewSearchPage([]domain.Item{newItem(t, "album", "album")}, 1, opts),
+				"track": spotify.NewSearchPage(nil, 0, opts),
+			}, nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, "spotify:v2:g0:album:::20:0:twice", mock.Anything, time.Hour*24).
+			Return(nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, "spotify:v2:g0:track:::20:0:twice", []byte(domain.NotFoundCacheValue), spotify.DefaultNegativeCacheTTL).
+			Return(nil).
+			Once()
+
+		pages, err := s.MultiSearch(context.Background(), "TWICE", []string{"artist", "album", "track", "album"}, spotify.SearchOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, pages, 3)
+		assert.Equal(t, []domain.Item{newItem(t, "artist", "cached artist")}, pages["artist"].Items)
+		assert.Equal(t, []domain.Item{newItem(t, "album", "album")}, pages["album"].Items)
+		assert.Empty(t, pages["track"].Items)
+	})
+
+	mockedCache.AssertExpectations(t)
+	mockedSpotifyClient.AssertExpectations(t)
+}
+
+func TestSpotifySearchService_Market(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+
+	s := spotify.New(
+		otel.Tracer("test"),
+		mockedSpotifyClient,
+		mockedCache,
+		spotify.Config{DefaultMarket: "US"},
+	)
+
+	t.Run("invalid market", func(t *testing.T) {
+		_, err := s.Search(context.Background(), "TWICE", "artist", spotify.SearchOptions{Market: "FRA"}, spotify.MatchOptions{})
+		assert.ErrorIs(t, err, spotify.ErrInvalidMarket)
+	})
+
+	t.Run("invalid locale", func(t *testing.T) {
+		_, err := s.Search(context.Background(), "TWICE", "artist", spotify.SearchOptions{Locale: "fr-FR"}, spotify.MatchOptions{})
+		assert.ErrorIs(t, err, spotify.ErrInvalidLocale)
+	})
+
+	t.Run("default market", func(t *testing.T) {
+		opts := spotify.SearchOptions{Limit: 10, Market: "US"}
+
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist:US::10:0:twice").
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"artist"}, opts).
+			Return(map[string]*spotify.SearchPage{
+				"artist": spotify.NewSearchPage([]domain.Item{newItem(t, "artist", "TWICE")}, 1, opts),
+			}, nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, "spotify:v2:g0:artist:US::10:0:twice", mock.Anything, time.Hour*24).
+			Return(nil).
+			Once()
+
+		_, err := s.Search(context.Background(), "TWICE", "artist", spotify.SearchOptions{}, spotify.MatchOptions{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("market and locale in cache key", func(t *testing.T) {
+		mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist:KR:ko_KR:10:0:twice").
+			Return(`{"items": [{"type": "artist", "name": "TWICE"}], "total": 1}`, nil).
+			Once()
+
+		_, err := s.Search(context.Background(), "TWICE", "artist", spotify.SearchOptions{Market: "kr", Locale: "ko_KR"}, spotify.MatchOptions{})
 		assert.NoError(t, err)
 	})
+
+	mockedCache.AssertExpectations(t)
+	mockedSpotifyClient.AssertExpectations(t)
+}
+
+func TestSpotifySearchService_Filters(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		searchType    string
+		filters       spotify.SearchFilters
+		expectedQuery string
+		expectedKey   string
+		expectedErr   error
+	}{
+		{
+			name:          "escaped query",
+			query:         "Daft%20Punk",
+			searchType:    "artist",
+			expectedQuery: "Daft Punk",
+			expectedKey:   "daft punk",
+		},
+		{
+			name:          "filters only",
+			searchType:    "track",
+			filters:       spotify.SearchFilters{Track: "Get Lucky", Artist: "Daft Punk"},
+			expectedQuery: `artist:"Daft Punk" track:"Get Lucky"`,
+			expectedKey:   `artist:"daft punk" track:"get lucky"`,
+		},
+		{
+			name:          "query with filters",
+			query:         "remaster",
+			searchType:    "album",
+			filters:       spotify.SearchFilters{Year: "1990-1999", Tag: "NEW", Artist: ` "Blur" `},
+			expectedQuery: "remaster artist:Blur year:1990-1999 tag:new",
+			expectedKey:   "remaster artist:blur year:1990-1999 tag:new",
+		},
+		{
+			name:          "single year range",
+			searchType:    "artist",
+			filters:       spotify.SearchFilters{Year: "2015-2015", Genre: "k-pop"},
+			expectedQuery: "year:2015 genre:k-pop",
+			expectedKey:   "year:2015 genre:k-pop",
+		},
+		{
+			name:          "isrc",
+			searchType:    "track",
+			filters:       spotify.SearchFilters{ISRC: "usum71703861"},
+			expectedQuery: "isrc:USUM71703861",
+			expectedKey:   "isrc:USUM71703861",
+		},
+		{
+			name:        "invalid isrc",
+			searchType:  "track",
+			filters:     spotify.SearchFilters{ISRC: "nope"},
+			expectedErr: spotify.ErrInvalidFilter,
+		},
+		{
+			name:        "invalid year",
+			searchType:  "track",
+			filters:     spotify.SearchFilters{Year: "1999-1990"},
+			expectedErr: spotify.ErrInvalidFilter,
+		},
+		{
+			name:        "invalid tag",
+			searchType:  "album",
+			filters:     spotify.SearchFilters{Tag: "old"},
+			expectedErr: spotify.ErrInvalidFilter,
+		},
+		{
+			name:        "filter not applicable to type",
+			searchType:  "artist",
+			filters:     spotify.SearchFilters{UPC: "602537518357"},
+			expectedErr: spotify.ErrInvalidFilter,
+		},
+		{
+			name:        "empty query",
+			query:       "%20",
+			searchType:  "artist",
+			expectedErr: spotify.ErrInvalidQuery,
+		},
+		{
+			name:        "malformed escape",
+			query:       "100%",
+			searchType:  "artist",
+			expectedErr: spotify.ErrInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedSpotifyClient := &mocks.MockSpotifyClient{}
+			mockedCache := &mocks.MockCache{}
+			t.Cleanup(func() {
+				mockedCache.AssertExpectations(t)
+				mockedSpotifyClient.AssertExpectations(t)
+			})
+
+			s := spotify.New(
+				otel.Tracer("test"),
+				mockedSpotifyClient,
+				mockedCache,
+				spotify.Config{},
+			)
+
+			opts := spotify.SearchOptions{Limit: spotify.DefaultSearchLimit}
+
+			if tt.expectedErr == nil {
+				mockedCache.On("Get", mock.Anything, "spotify:v2:g0:"+tt.searchType+":::20:0:"+tt.expectedKey).
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				mockedSpotifyClient.On("Search", mock.Anything, tt.expectedQuery, []string{tt.searchType}, opts).
+					Return(map[string]*spotify.SearchPage{}, nil).
+					Once()
+				mockedCache.On("Set", mock.Anything, "spotify:v2:g0:"+tt.searchType+":::20:0:"+tt.expectedKey, mock.Anything, spotify.DefaultNegativeCacheTTL).
+					Return(nil).
+					Once()
+			}
+
+			_, err := s.SearchPage(context.Background(), tt.query, tt.searchType, spotify.SearchOptions{Filters: tt.filters})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSpotifySearchService_CachedSchema(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+
+	s := spotify.New(
+		otel.Tracer("test"),
+		mockedSpotifyClient,
+		mockedCache,
+		spotify.Config{},
+	)
+
+	opts := spotify.SearchOptions{Limit: spotify.DefaultSearchLimit}
+	items := []domain.Item{
+		&domain.Artist{
+			Object: domain.Object{
+				ID:           "7n2Ycct7Beij7Dj7meI4X0",
+				Type:         "artist",
+				Name:         "TWICE",
+				URI:          "spotify:artist:7n2Ycct7Beij7Dj7meI4X0",
+				ExternalURLs: map[string]string{"spotify": "https://open.spotify.com/artist/7n2Ycct7Beij7Dj7meI4X0"},
+			},
+			Genres:     []string{"k-pop", "k-pop girl group"},
+			Images:     []domain.Image{{URL: "https://i.scdn.co/image/twice", Height: 640, Width: 640}},
+			Popularity: 75,
+			Followers:  domain.Followers{Total: 17000000},
+		},
+		&domain.Track{
+			Object:  domain.Object{ID: "track", Type: "track", Name: "FANCY"},
+			Album:   domain.SimpleAlbum{Object: domain.Object{ID: "album", Type: "album", Name: "FANCY YOU"}},
+			Artists: []domain.SimpleArtist{{Object: domain.Object{ID: "7n2Ycct7Beij7Dj7meI4X0", Type: "artist", Name: "TWICE"}}},
+		},
+	}
+
+	var cached []byte
+	mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist:::20:0:twice").
+		Return("", spotify.ErrCacheMiss).
+		Once()
+	mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"artist"}, opts).
+		Return(map[string]*spotify.SearchPage{
+			"artist": spotify.NewSearchPage(items, 2, opts),
+		}, nil).
+		Once()
+	mockedCache.On("Set", mock.Anything, "spotify:v2:g0:artist:::20:0:twice", mock.Anything, time.Hour*24).
+		Run(func(args mock.Arguments) {
+			cached = args.Get(2).([]byte)
+		}).
+		Return(nil).
+		Once()
+
+	missPage, err := s.SearchPage(context.Background(), "TWICE", "artist", spotify.SearchOptions{})
+	require.NoError(t, err)
+
+	mockedCache.On("Get", mock.Anything, "spotify:v2:g0:artist:::20:0:twice").
+		Return(string(cached), nil).
+		Once()
+
+	hitPage, err := s.SearchPage(context.Background(), "TWICE", "artist", spotify.SearchOptions{})
+	require.NoError(t, err)
+
+	missJSON, err := json.Marshal(missPage)
+	require.NoError(t, err)
+	hitJSON, err := json.Marshal(hitPage)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(missJSON), string(hitJSON))
+	assert.IsType(t, &domain.Track{}, hitPage.Items[1])
+
+	mockedCache.AssertExpectations(t)
+	mockedSpotifyClient.AssertExpectations(t)
+}
+
+func TestSpotifySearchService_BestMatch(t *testing.T) {
+	opts := spotify.SearchOptions{Limit: 10}
+
+	tests := []struct {
+		name               string
+		query              string
+		searchType         string
+		filters            spotify.SearchFilters
+		expectedQuery      string
+		expectedKey        string
+		results            []string
+		matchOpts          spotify.MatchOptions
+		expectedName       string
+		expectedCandidates int
+		expectedErr        error
+	}{
+		{
+			name:          "exact match ranked below a tribute",
+			query:         "TWICE",
+			searchType:    "artist",
+			expectedQuery: "TWICE",
+			expectedKey:   "twice",
+			results:       []string{"TWICE Tribute Band", "TWICE"},
+			expectedName:  "TWICE",
+		},
+		{
+			name:          "diacritics and case",
+			query:         "beyonce",
+			searchType:    "artist",
+			expectedQuery: "beyonce",
+			expectedKey:   "beyonce",
+			results:       []string{"Beyoncé Karaoke", "Beyoncé"},
+			expectedName:  "Beyoncé",
+		},
+		{
+			name:          "noise words the query asked for",
+			query:         "TWICE karaoke",
+			searchType:    "album",
+			expectedQuery: "TWICE karaoke",
+			expectedKey:   "twice karaoke",
+			results:       []string{"Formula of Love", "TWICE Karaoke"},
+			expectedName:  "TWICE Karaoke",
+		},
+		{
+			name:          "compared to the filter of the type",
+			searchType:    "track",
+			filters:       spotify.SearchFilters{Artist: "TWICE", Track: "FANCY"},
+			expectedQuery: "artist:TWICE track:FANCY",
+			expectedKey:   "artist:twice track:fancy",
+			results:       []string{"FANCY YOU", "FANCY"},
+			expectedName:  "FANCY",
+		},
+		{
+			name:               "explain",
+			query:              "TWICE",
+			searchType:         "artist",
+			expectedQuery:      "TWICE",
+			expectedKey:        "twice",
+			results:            []string{"TWICE Tribute Band", "TWICE", "TWlCE"},
+			matchOpts:          spotify.MatchOptions{Explain: true},
+			expectedName:       "TWICE",
+			expectedCandidates: 3,
+		},
+		{
+			name:          "below the minimum confidence",
+			query:         "TWICE",
+			searchType:    "artist",
+			expectedQuery: "TWICE",
+			expectedKey:   "twice",
+			results:       []string{"Tribute to TWICE"},
+			matchOpts:     spotify.MatchOptions{MinConfidence: 0.8},
+			expectedErr:   spotify.ErrNoResultsFound,
+		},
+		{
+			name:        "invalid minimum confidence",
+			query:       "TWICE",
+			searchType:  "artist",
+			matchOpts:   spotify.MatchOptions{MinConfidence: 1.5},
+			expectedErr: spotify.ErrInvalidMinConfidence,
+		},
+		{
+			name:        "NaN minimum confidence",
+			query:       "TWICE",
+			searchType:  "artist",
+			matchOpts:   spotify.MatchOptions{MinConfidence: math.NaN()},
+			expectedErr: spotify.ErrInvalidMinConfidence,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedSpotifyClient := &mocks.MockSpotifyClient{}
+			mockedCache := &mocks.MockCache{}
+			t.Cleanup(func() {
+				mockedCache.AssertExpectations(t)
+				mockedSpotifyClient.AssertExpectations(t)
+			})
+
+			s := spotify.New(
+				otel.Tracer("test"),
+				mockedSpotifyClient,
+				mockedCache,
+				spotify.Config{},
+			)
+
+			if tt.results != nil {
+				items := make([]domain.Item, 0, len(tt.results))
+				for _, name := range tt.results {
+					items = append(items, newItem(t, tt.searchType, name))
+				}
+
+				mockedCache.On("Get", mock.Anything, "spotify:v2:g0:"+tt.searchType+":::10:0:"+tt.expectedKey).
+					Return("", spotify.ErrCacheMiss).
+					Once()
+				mockedSpotifyClient.On("Search", mock.Anything, tt.expectedQuery, []string{tt.searchType}, opts).
+					Return(map[string]*spotify.SearchPage{
+						tt.searchType: spotify.NewSearchPage(items, len(items), opts),
+					}, nil).
+					Once()
+				mockedCache.On("Set", mock.Anything, "spotify:v2:g0:"+tt.searchType+":::10:0:"+tt.expectedKey, mock.Anything, time.Hour*24).
+					Return(nil).
+					Once()
+			}
+
+			match, err := s.Search(context.Background(), tt.query, tt.searchType, spotify.SearchOptions{Filters: tt.filters}, tt.matchOpts)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedName, match.Item.ObjectName())
+			assert.Greater(t, match.Confidence, 0.0)
+			assert.LessOrEqual(t, match.Confidence, 1.0)
+			assert.Len(t, match.Candidates, tt.expectedCandidates)
+			if tt.expectedCandidates > 0 {
+				assert.Equal(t, match.Confidence, match.Candidates[0].Score)
+			}
+		})
+	}
+}
+
+func TestSpotifySearchService_BestMatch_concurrent(t *testing.T) {
+	mockedCache := &mocks.MockCache{}
+	defer mockedCache.AssertExpectations(t)
+
+	s := spotify.New(otel.Tracer("test"), &mocks.MockSpotifyClient{}, mockedCache, spotify.Config{})
+
+	name := strings.Repeat("Beyoncé ", 128)
+	cached, err := json.Marshal(map[string]any{
+		"items": []domain.Item{newItem(t, "artist", name+"Karaoke"), newItem(t, "artist", name)},
+		"total": 2,
+	})
+	require.NoError(t, err)
+
+	mockedCache.On("Get", mock.Anything, mock.Anything).
+		Return(string(cached), nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			match, err := s.Search(context.Background(), name, "artist", spotify.SearchOptions{}, spotify.MatchOptions{})
+			if assert.NoError(t, err) {
+				assert.Equal(t, name, match.Item.ObjectName())
+			}
+		}()
+	}
+	wg.Wait()
 }
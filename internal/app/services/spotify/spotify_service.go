@@ -2,30 +2,92 @@ package spotify
 
 import (
 	"fmt"
+	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
+type Config struct {
+	// DefaultMarket is used when a search doesn't specify a market.
+	DefaultMarket string
+	// BatchConcurrency is the maximum number of concurrent upstream searches
+	// of a batch.
+	BatchConcurrency int
+	// ShortLinkResolver follows spotify.link short links. They can't be
+	// resolved when it is nil.
+	ShortLinkResolver ShortLinkResolver
+	// MaxAlbumTracks is the maximum number of tracks returned for an album,
+	// DefaultMaxAlbumTracks when zero.
+	MaxAlbumTracks int
+	// SuggestDebounce is how long suggestions wait for a newer query of the
+	// same client before searching Spotify. Zero disables debouncing.
+	SuggestDebounce time.Duration
+	// QueryNormalization is the normalization of the queries of each search
+	// type. Types without one use DefaultQueryNormalization.
+	QueryNormalization map[string]QueryNormalization
+	// TTLs is how long results are cached.
+	TTLs TTLPolicy
+	// Locker coalesces the identical cache misses of several instances. They
+	// are only coalesced within each instance when it is nil.
+	Locker Locker
+	// Meter records the metrics of the service, the global one's when nil.
+	Meter metric.Meter
+	// Namespace prefixes the cache keys, DefaultNamespace when empty.
+	Namespace string
+	// Sweeper deletes the cache keys of the previous generations. They are
+	// left to expire when it is nil.
+	Sweeper Sweeper
+}
+
 type SpotifySearchService struct {
 	tracer        trace.Tracer
 	spotifyClient SpotifyClient
 	cache         Cache
+	config        Config
+	debouncer     *debouncer
+	refresher     *refresher
+	coalescer     *coalescer
+	keyspace      *keyspace
 }
 
 func New(
 	tracer trace.Tracer,
 	spotifyClient SpotifyClient,
 	cache Cache,
+	config Config,
 ) SpotifySearchService {
+	meter := config.Meter
+	if meter == nil {
+		meter = otel.Meter("spotify-search-proxy")
+	}
+
 	return SpotifySearchService{
 		tracer:        tracer,
 		spotifyClient: spotifyClient,
 		cache:         cache,
+		config:        config,
+		debouncer:     newDebouncer(),
+		refresher:     newRefresher(),
+		coalescer:     newCoalescer(meter),
+		keyspace:      newKeyspace(config.Namespace),
 	}
 }
 
 var (
-	ErrInvalidQueryType = fmt.Errorf("invalid query type")
-	ErrNoResultsFound   = fmt.Errorf("no results found")
-	ErrSpotifyClient    = fmt.Errorf("spotify client error")
+	ErrInvalidQueryType     = fmt.Errorf("invalid query type")
+	ErrNoResultsFound       = fmt.Errorf("no results found")
+	ErrSpotifyClient        = fmt.Errorf("spotify client error")
+	ErrInvalidPagination    = fmt.Errorf("invalid pagination")
+	ErrInvalidMarket        = fmt.Errorf("invalid market")
+	ErrInvalidLocale        = fmt.Errorf("invalid locale")
+	ErrInvalidQuery         = fmt.Errorf("invalid query")
+	ErrInvalidFilter        = fmt.Errorf("invalid filter")
+	ErrInvalidMinConfidence = fmt.Errorf("invalid min_confidence")
+	ErrInvalidBatch         = fmt.Errorf("invalid batch")
+	ErrInvalidID            = fmt.Errorf("invalid id")
+	ErrNotFound             = fmt.Errorf("not found")
+	ErrInvalidLink          = fmt.Errorf("invalid link")
+	ErrInvalidTTLPolicy     = fmt.Errorf("invalid TTL policy")
 )
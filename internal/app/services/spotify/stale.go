@@ -0,0 +1,138 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// refreshTimeout bounds the background refresh of a stale entry, as it is no
+// longer bound to the request that triggered it.
+const refreshTimeout = time.Second * 30
+
+// CacheStatus records how the results of a request were served from the
+// cache.
+type CacheStatus struct {
+	stale atomic.Bool
+}
+
+type cacheStatusKey struct{}
+
+// WithCacheStatus returns a context whose requests record their cache status
+// in the returned CacheStatus.
+func WithCacheStatus(ctx context.Context) (context.Context, *CacheStatus) {
+	status := &CacheStatus{}
+	return context.WithValue(ctx, cacheStatusKey{}, status), status
+}
+
+// CacheStatusFrom returns the cache status of the context, or nil if it
+// doesn't record one.
+func CacheStatusFrom(ctx context.Context) *CacheStatus {
+	status, _ := ctx.Value(cacheStatusKey{}).(*CacheStatus)
+	return status
+}
+
+// Stale tells whether any of the results was stale.
+func (s *CacheStatus) Stale() bool {
+	return s != nil && s.stale.Load()
+}
+
+// MarkStale records that a stale result was served.
+func (s *CacheStatus) MarkStale() {
+	if s != nil {
+		s.stale.Store(true)
+	}
+}
+
+// cacheEntry is a cached value along with the time it becomes stale, when
+// stale-while-revalidate is enabled. Otherwise values are cached bare.
+type cacheEntry struct {
+	StaleAt int64           `json:"stale_at"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// encodeEntry returns the value to cache for a payload with the given TTL.
+// With TTLPolicy.Stale, the payload is wrapped in an entry that becomes stale
+// after the TTL.
+func (s SpotifySearchService) encodeEntry(payload []byte, ttl time.Duration) []byte {
+	if s.config.TTLs.Stale <= 0 {
+		return payload
+	}
+
+	value, err := json.Marshal(cacheEntry{
+		StaleAt: time.Now().Add(ttl).Unix(),
+		Value:   payload,
+	})
+	if err != nil {
+		return payload
+	}
+	return value
+}
+
+// keyTTL returns the TTL of the cache key of an entry with the given TTL,
+// which expires TTLPolicy.Stale later so that it can be served stale.
+func (s SpotifySearchService) keyTTL(ttl time.Duration) time.Duration {
+	return ttl + max(s.config.TTLs.Stale, 0)
+}
+
+// decodeEntry returns the payload of a cached value, and whether it is stale.
+// Bare values are never stale.
+func decodeEntry(value string) ([]byte, bool) {
+	var entry cacheEntry
+	if err := json.Unmarshal([]byte(value), &entry); err != nil || entry.StaleAt == 0 || len(entry.Value) == 0 {
+		return []byte(value), false
+	}
+	return entry.Value, time.Now().Unix() >= entry.StaleAt
+}
+
+// refresher keeps track of the keys being refreshed, so that each key is only
+// refreshed once at a time.
+type refresher struct {
+	mu         sync.Mutex
+	refreshing map[string]struct{}
+}
+
+func newRefresher() *refresher {
+	return &refresher{refreshing: map[string]struct{}{}}
+}
+
+// serveStale marks the request as served stale, and refreshes the key in
+// the background unless it is already being refreshed. When the refresh
+// fails, the stale value keeps being served until the key expires.
+func (s SpotifySearchService) serveStale(ctx context.Context, key string, refresh func(ctx context.Context) error) {
+	CacheStatusFrom(ctx).MarkStale()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("stale", true))
+
+	s.refresher.mu.Lock()
+	if _, ok := s.refresher.refreshing[key]; ok {
+		s.refresher.mu.Unlock()
+		return
+	}
+	s.refresher.refreshing[key] = struct{}{}
+	s.refresher.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.refresher.mu.Lock()
+			delete(s.refresher.refreshing, key)
+			s.refresher.mu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+
+		ctx, span := s.tracer.Start(ctx, "SpotifySearchService.refresh")
+		defer span.End()
+
+		span.SetAttributes(attribute.String("key", key))
+
+		if err := refresh(ctx); err != nil {
+			span.RecordError(err)
+		}
+	}()
+}
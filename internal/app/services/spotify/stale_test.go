@@ -0,0 +1,245 @@
+package spotify_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func waitFor(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the background refresh")
+	}
+}
+
+func TestSpotifySearchService_StaleWhileRevalidate(t *testing.T) {
+	opts := spotify.SearchOptions{Limit: 10}
+	key := "spotify:v2:g0:artist:::10:0:twice"
+	staleTTL := time.Hour * 24
+
+	newService := func(t *testing.T) (spotify.SpotifySearchService, *mocks.MockSpotifyClient, *mocks.MockCache) {
+		mockedSpotifyClient := &mocks.MockSpotifyClient{}
+		mockedCache := &mocks.MockCache{}
+		t.Cleanup(func() {
+			mockedCache.AssertExpectations(t)
+			mockedSpotifyClient.AssertExpectations(t)
+		})
+
+		s := spotify.New(
+			otel.Tracer("test"),
+			mockedSpotifyClient,
+			mockedCache,
+			spotify.Config{TTLs: spotify.TTLPolicy{Stale: staleTTL}},
+		)
+		return s, mockedSpotifyClient, mockedCache
+	}
+
+	entry := func(staleAt time.Time) string {
+		return fmt.Sprintf(`{"stale_at": %d, "value": {"items": [{"type": "artist", "name": "cached"}], "total": 1}}`, staleAt.Unix())
+	}
+
+	t.Run("miss", func(t *testing.T) {
+		s, mockedSpotifyClient, mockedCache := newService(t)
+
+		mockedCache.On("Get", mock.Anything, key).
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"artist"}, opts).
+			Return(map[string]*spotify.SearchPage{
+				"artist": spotify.NewSearchPage([]domain.Item{newItem(t, "artist", "TWICE")}, 1, opts),
+			}, nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, key, mock.MatchedBy(func(value []byte) bool {
+			var entry struct {
+				StaleAt int64           `json:"stale_at"`
+				Value   json.RawMessage `json:"value"`
+			}
+			if err := json.Unmarshal(value, &entry); err != nil {
+				return false
+			}
+			staleAt := time.Unix(entry.StaleAt, 0)
+			return staleAt.After(time.Now().Add(spotify.DefaultSearchTTL-time.Minute)) && len(entry.Value) > 0
+		}), spotify.DefaultSearchTTL+staleTTL).
+			Return(nil).
+			Once()
+
+		ctx, status := spotify.WithCacheStatus(context.Background())
+		page, err := s.SearchPage(ctx, "TWICE", "artist", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "TWICE", page.Items[0].ObjectName())
+		assert.False(t, status.Stale())
+	})
+
+	t.Run("fresh", func(t *testing.T) {
+		s, _, mockedCache := newService(t)
+
+		mockedCache.On("Get", mock.Anything, key).
+			Return(entry(time.Now().Add(time.Hour)), nil).
+			Once()
+
+		ctx, status := spotify.WithCacheStatus(context.Background())
+		page, err := s.SearchPage(ctx, "TWICE", "artist", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "cached", page.Items[0].ObjectName())
+		assert.False(t, status.Stale())
+	})
+
+	t.Run("bare value", func(t *testing.T) {
+		s, _, mockedCache := newService(t)
+
+		mockedCache.On("Get", mock.Anything, key).
+			Return(`{"items": [{"type": "artist", "name": "cached"}], "total": 1}`, nil).
+			Once()
+
+		ctx, status := spotify.WithCacheStatus(context.Background())
+		page, err := s.SearchPage(ctx, "TWICE", "artist", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "cached", page.Items[0].ObjectName())
+		assert.False(t, status.Stale())
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		s, mockedSpotifyClient, mockedCache := newService(t)
+
+		refreshed := make(chan struct{})
+		mockedCache.On("Get", mock.Anything, key).
+			Return(entry(time.Now().Add(-time.Minute)), nil).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"artist"}, opts).
+			Return(map[string]*spotify.SearchPage{
+				"artist": spotify.NewSearchPage([]domain.Item{newItem(t, "artist", "TWICE")}, 1, opts),
+			}, nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, key, mock.Anything, spotify.DefaultSearchTTL+staleTTL).
+			Return(nil).
+			Run(func(mock.Arguments) { close(refreshed) }).
+			Once()
+
+		ctx, status := spotify.WithCacheStatus(context.Background())
+		page, err := s.SearchPage(ctx, "TWICE", "artist", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "cached", page.Items[0].ObjectName())
+		assert.True(t, status.Stale())
+
+		waitFor(t, refreshed)
+	})
+
+	t.Run("stale refresh error", func(t *testing.T) {
+		s, mockedSpotifyClient, mockedCache := newService(t)
+
+		refreshed := make(chan struct{})
+		mockedCache.On("Get", mock.Anything, key).
+			Return(entry(time.Now().Add(-time.Minute)), nil).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{"artist"}, opts).
+			Return(nil, errors.New("service unavailable")).
+			Run(func(mock.Arguments) { close(refreshed) }).
+			Once()
+
+		ctx, status := spotify.WithCacheStatus(context.Background())
+		page, err := s.SearchPage(ctx, "TWICE", "artist", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "cached", page.Items[0].ObjectName())
+		assert.True(t, status.Stale())
+
+		waitFor(t, refreshed)
+	})
+
+	t.Run("stale resource", func(t *testing.T) {
+		s, mockedSpotifyClient, mockedCache := newService(t)
+
+		refreshed := make(chan struct{})
+		relatedKey := "spotify:v2:g0:artist-related:" + twiceID
+		mockedCache.On("Get", mock.Anything, relatedKey).
+			Return(fmt.Sprintf(`{"stale_at": %d, "value": [{"type": "artist", "name": "cached"}]}`, time.Now().Add(-time.Minute).Unix()), nil).
+			Once()
+		mockedSpotifyClient.On("GetRelatedArtists", mock.Anything, twiceID).
+			Return([]domain.Item{newItem(t, "artist", "ITZY")}, nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, relatedKey, mock.Anything, time.Hour*24*7+staleTTL).
+			Return(nil).
+			Run(func(mock.Arguments) { close(refreshed) }).
+			Once()
+
+		ctx, status := spotify.WithCacheStatus(context.Background())
+		artists, err := s.RelatedArtists(ctx, twiceID)
+		require.NoError(t, err)
+		assert.Equal(t, "cached", artists[0].ObjectName())
+		assert.True(t, status.Stale())
+
+		waitFor(t, refreshed)
+	})
+
+	t.Run("stale by id", func(t *testing.T) {
+		s, mockedSpotifyClient, mockedCache := newService(t)
+
+		idEntry := func(id string, name string, staleAt time.Time) string {
+			return fmt.Sprintf(`{"stale_at": %d, "value": {"type": "artist", "id": %q, "name": %q}}`, staleAt.Unix(), id, name)
+		}
+
+		refreshed := make(chan struct{})
+		twiceKey := "spotify:v2:g0:id:artist::" + twiceID
+		ivesKey := "spotify:v2:g0:id:artist::" + ivesID
+		mockedCache.On("MGet", mock.Anything, []string{twiceKey, ivesKey}).
+			Return([]string{
+				idEntry(twiceID, "cached", time.Now().Add(-time.Minute)),
+				idEntry(ivesID, "IVE", time.Now().Add(time.Hour)),
+			}, nil).
+			Once()
+		mockedSpotifyClient.On("GetArtists", mock.Anything, []string{twiceID}).
+			Return([]domain.Item{newItemWithID(t, "artist", twiceID, "TWICE")}, nil).
+			Once()
+		mockedCache.On("MSet", mock.Anything, mock.MatchedBy(func(values map[string][]byte) bool {
+			_, ok := values[twiceKey]
+			return len(values) == 1 && ok
+		}), time.Hour*24+staleTTL).
+			Return(nil).
+			Run(func(mock.Arguments) { close(refreshed) }).
+			Once()
+
+		ctx, status := spotify.WithCacheStatus(context.Background())
+		items, err := s.GetByIDs(ctx, "artist", []string{twiceID, ivesID}, "")
+		require.NoError(t, err)
+		assert.Equal(t, "cached", items[0].ObjectName())
+		assert.Equal(t, "IVE", items[1].ObjectName())
+		assert.True(t, status.Stale())
+
+		waitFor(t, refreshed)
+	})
+
+	t.Run("stale by id refresh error", func(t *testing.T) {
+		s, mockedSpotifyClient, mockedCache := newService(t)
+
+		refreshed := make(chan struct{})
+		mockedCache.On("MGet", mock.Anything, []string{"spotify:v2:g0:id:artist::" + twiceID}).
+			Return([]string{fmt.Sprintf(`{"stale_at": %d, "value": {"type": "artist", "id": %q, "name": "cached"}}`, time.Now().Add(-time.Minute).Unix(), twiceID)}, nil).
+			Once()
+		mockedSpotifyClient.On("GetArtists", mock.Anything, []string{twiceID}).
+			Return(nil, errors.New("service unavailable")).
+			Run(func(mock.Arguments) { close(refreshed) }).
+			Once()
+
+		ctx, status := spotify.WithCacheStatus(context.Background())
+		item, err := s.GetByID(ctx, "artist", twiceID, "")
+		require.NoError(t, err)
+		assert.Equal(t, "cached", item.ObjectName())
+		assert.True(t, status.Stale())
+
+		waitFor(t, refreshed)
+	})
+}
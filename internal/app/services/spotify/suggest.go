@@ -0,0 +1,374 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	DefaultSuggestLimit = 5
+	MaxSuggestLimit     = 20
+	// MaxSuggestQueryLength bounds the number of prefixes looked up in the
+	// cache.
+	MaxSuggestQueryLength = 100
+)
+
+// Each prefix is searched with Spotify's largest page, so that its results
+// can be filtered for the longer prefixes typed next.
+const suggestFetchLimit = MaxSearchLimit
+
+// ErrSuperseded is returned when a newer query of the same client came in
+// while the suggestions were debounced.
+var ErrSuperseded = fmt.Errorf("superseded by a newer query")
+
+// Suggestion is a lightweight search result for search-as-you-type.
+type Suggestion struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Image is the URL of the smallest image, if any.
+	Image string `json:"image"`
+}
+
+// SuggestOptions are the options of Suggest. Suggestions are debounced per
+// Client, and not at all when it is empty.
+type SuggestOptions struct {
+	Limit  int
+	Market string
+	Client string
+}
+
+// suggestionSet is the cached suggestions of a prefix. They are complete
+// when Spotify had no more results.
+type suggestionSet struct {
+	Suggestions []Suggestion `json:"suggestions"`
+	Complete    bool         `json:"complete"`
+}
+
+// Suggest returns up to opts.Limit suggestions per type, in the order of the
+// types. The results of a shorter prefix of the query are reused when they
+// are cached and enough of them still match. Otherwise Spotify is only
+// searched once no newer query of the same client came in for
+// Config.SuggestDebounce.
+func (s SpotifySearchService) Suggest(ctx context.Context, query string, searchTypes []string, opts SuggestOptions) ([]Suggestion, error) {
+	ctx, span := s.tracer.Start(ctx, "SpotifySearchService.Suggest")
+	defer span.End()
+
+	searchTypes = uniqueSearchTypes(searchTypes)
+	for _, searchType := range searchTypes {
+		if !slices.Contains(SearchTypes, searchType) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidQueryType, searchType)
+		}
+	}
+
+	query = normalizeSuggestQuery(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: q is required", ErrInvalidQuery)
+	}
+	if utf8.RuneCountInString(query) > MaxSuggestQueryLength {
+		return nil, fmt.Errorf("%w: q must be at most %d characters", ErrInvalidQuery, MaxSuggestQueryLength)
+	}
+
+	if opts.Limit == 0 {
+		opts.Limit = DefaultSuggestLimit
+	}
+	if opts.Limit < 1 || opts.Limit > MaxSuggestLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidPagination, MaxSuggestLimit)
+	}
+
+	market, err := s.market(opts.Market)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("query", query),
+		attribute.StringSlice("types", searchTypes),
+		attribute.String("market", market),
+	)
+
+	suggestions, missingTypes := s.cachedSuggestions(ctx, query, searchTypes, market, opts.Limit)
+
+	span.SetAttributes(attribute.StringSlice("missing_types", missingTypes))
+
+	if len(missingTypes) > 0 {
+		if opts.Client != "" {
+			if err := s.debouncer.wait(ctx, opts.Client, s.config.SuggestDebounce); err != nil {
+				return nil, err
+			}
+		}
+
+		fetched, err := s.fetchSuggestions(ctx, query, missingTypes, market)
+		if err != nil {
+			return nil, err
+		}
+		for searchType, set := range fetched {
+			suggestions[searchType] = set.Suggestions
+		}
+	}
+
+	result := []Suggestion{}
+	for _, searchType := range searchTypes {
+		typeSuggestions := suggestions[searchType]
+		result = append(result, typeSuggestions[:min(opts.Limit, len(typeSuggestions))]...)
+	}
+
+	return result, nil
+}
+
+// cachedSuggestions returns the suggestions of each type that can be built
+// from the cache, along with the types that must be searched. The cache is
+// looked up for the query and all its prefixes at once, and the longest
+// cached prefix is used.
+func (s SpotifySearchService) cachedSuggestions(ctx context.Context, query string, searchTypes []string, market string, limit int) (map[string][]Suggestion, []string) {
+	prefixes := suggestPrefixes(query)
+
+	keys := make([]string, 0, len(searchTypes)*len(prefixes))
+	for _, searchType := range searchTypes {
+		for _, prefix := range prefixes {
+			keys = append(keys, s.suggestCacheKey(searchType, market, prefix))
+		}
+	}
+
+	values, err := s.cache.MGet(ctx, keys)
+	if err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+		values = make([]string, len(keys))
+	}
+
+	suggestions := make(map[string][]Suggestion, len(searchTypes))
+	var missingTypes []string
+	for i, searchType := range searchTypes {
+		if matching, ok := reusePrefix(values[i*len(prefixes):(i+1)*len(prefixes)], prefixes, query, limit); ok {
+			suggestions[searchType] = matching
+			continue
+		}
+		missingTypes = append(missingTypes, searchType)
+	}
+
+	return suggestions, missingTypes
+}
+
+// reusePrefix returns the suggestions of the longest cached prefix that
+// match the query. The suggestions of a shorter prefix can be reused when
+// Spotify returned all of its results, or when enough of them match.
+func reusePrefix(values []string, prefixes []string, query string, limit int) ([]Suggestion, bool) {
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+
+		var set suggestionSet
+		if err := json.Unmarshal([]byte(value), &set); err != nil {
+			continue
+		}
+
+		if prefixes[i] == query {
+			return set.Suggestions, true
+		}
+
+		var matching []Suggestion
+		for _, suggestion := range set.Suggestions {
+			if matchesPrefix(suggestion.Name, query) {
+				matching = append(matching, suggestion)
+			}
+		}
+		if set.Complete || len(matching) >= limit {
+			return matching, true
+		}
+
+		// A shorter prefix wouldn't have more matches
+		return nil, false
+	}
+
+	return nil, false
+}
+
+// fetchSuggestions searches Spotify for the query and caches the suggestions
+// of each type.
+func (s SpotifySearchService) fetchSuggestions(ctx context.Context, query string, searchTypes []string, market string) (map[string]suggestionSet, error) {
+	opts := SearchOptions{Limit: suggestFetchLimit, Market: market}
+
+	results, err := s.spotifyClient.Search(ctx, query, searchTypes, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSpotifyClient, err.Error())
+	}
+
+	sets := make(map[string]suggestionSet, len(searchTypes))
+	values := make(map[string][]byte, len(searchTypes))
+	for _, searchType := range searchTypes {
+		set := suggestionSet{Suggestions: []Suggestion{}, Complete: true}
+		if page := results[searchType]; page != nil {
+			for _, item := range page.Items {
+				set.Suggestions = append(set.Suggestions, newSuggestion(item))
+			}
+			set.Complete = page.Total <= len(page.Items)
+		}
+		sets[searchType] = set
+
+		value, err := json.Marshal(set)
+		if err != nil {
+			return nil, err
+		}
+		values[s.suggestCacheKey(searchType, market, query)] = value
+	}
+
+	if err := s.cache.MSet(ctx, values, s.ttl(s.resourceTTL("suggest"))); err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+
+	return sets, nil
+}
+
+func newSuggestion(item domain.Item) Suggestion {
+	return Suggestion{
+		Type:  item.ObjectType(),
+		ID:    item.ObjectID(),
+		Name:  item.ObjectName(),
+		Image: thumbnail(itemImages(item)),
+	}
+}
+
+func itemImages(item domain.Item) []domain.Image {
+	switch item := item.(type) {
+	case *domain.Artist:
+		return item.Images
+	case *domain.Album:
+		return item.Images
+	case *domain.Track:
+		return item.Album.Images
+	case *domain.Playlist:
+		return item.Images
+	case *domain.Show:
+		return item.Images
+	case *domain.Episode:
+		return item.Images
+	case *domain.Audiobook:
+		return item.Images
+	}
+	return nil
+}
+
+// thumbnail returns the URL of the smallest image.
+func thumbnail(images []domain.Image) string {
+	url := ""
+	smallest := 0
+	for _, image := range images {
+		// Images without a size, such as playlist mosaics, are only used
+		// when there's nothing else
+		size := image.Width * image.Height
+		if url == "" || (size > 0 && (smallest == 0 || size < smallest)) {
+			url, smallest = image.URL, size
+		}
+	}
+	return url
+}
+
+// normalizeSuggestQuery lower-cases the query and collapses its whitespace,
+// so that "Taylor  S" and "taylor s" share their cache.
+func normalizeSuggestQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+// suggestPrefixes returns the query and its prefixes, longest first.
+// Prefixes ending with a space are skipped, as they are the same query as
+// the prefix without it.
+func suggestPrefixes(query string) []string {
+	prefixes := []string{query}
+	for i := len(query) - 1; i > 0; i-- {
+		if !utf8.RuneStart(query[i]) || query[i-1] == ' ' {
+			continue
+		}
+		prefixes = append(prefixes, query[:i])
+	}
+	return prefixes
+}
+
+// matchesPrefix tells whether each word of the query starts a word of the
+// name, in order, which is how Spotify matches names of partially typed
+// queries.
+func matchesPrefix(name string, query string) bool {
+	words := strings.Fields(normalizeName(name))
+	for _, queryWord := range strings.Fields(normalizeName(query)) {
+		found := false
+		for len(words) > 0 {
+			word := words[0]
+			words = words[1:]
+			if strings.HasPrefix(word, queryWord) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (s SpotifySearchService) suggestCacheKey(searchType string, market string, prefix string) string {
+	return s.keyspace.key(fmt.Sprintf("suggest:%s:%s:%s", searchType, market, prefix))
+}
+
+// debouncer keeps track of the latest query of each client.
+type debouncer struct {
+	mu     sync.Mutex
+	seq    uint64
+	latest map[string]uint64
+}
+
+func newDebouncer() *debouncer {
+	return &debouncer{latest: map[string]uint64{}}
+}
+
+// wait waits for the delay, and returns ErrSuperseded if another query of the
+// client came in meanwhile.
+func (d *debouncer) wait(ctx context.Context, client string, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	d.mu.Lock()
+	d.seq++
+	seq := d.seq
+	d.latest[client] = seq
+	d.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		d.forget(client, seq)
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.latest[client] != seq {
+		return ErrSuperseded
+	}
+	delete(d.latest, client)
+	return nil
+}
+
+func (d *debouncer) forget(client string, seq uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.latest[client] == seq {
+		delete(d.latest, client)
+	}
+}
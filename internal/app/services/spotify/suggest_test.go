@@ -0,0 +1,231 @@
+package spotify_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func suggestionSet(t *testing.T, complete bool, names ...string) string {
+	suggestions := []spotify.Suggestion{}
+	for _, name := range names {
+		suggestions = append(suggestions, spotify.Suggestion{Type: "artist", ID: name, Name: name})
+	}
+	value, err := json.Marshal(map[string]any{"suggestions": suggestions, "complete": complete})
+	require.NoError(t, err)
+	return string(value)
+}
+
+func TestSpotifySearchService_Suggest(t *testing.T) {
+	twice, err := domain.UnmarshalItem([]byte(`{
+		"type": "artist", "id": "` + twiceID + `", "name": "TWICE",
+		"images": [
+			{"url": "https://i.scdn.co/640", "width": 640, "height": 640},
+			{"url": "https://i.scdn.co/160", "width": 160, "height": 160},
+			{"url": "https://i.scdn.co/320", "width": 320, "height": 320}
+		]
+	}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		query       string
+		types       []string
+		limit       int
+		setup       func(client *mocks.MockSpotifyClient, cache *mocks.MockCache)
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:  "cache miss",
+			query: " TW ",
+			types: []string{"artist"},
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("MGet", mock.Anything, []string{"spotify:v2:g0:suggest:artist::tw", "spotify:v2:g0:suggest:artist::t"}).
+					Return([]string{"", ""}, nil).
+					Once()
+				client.On("Search", mock.Anything, "tw", []string{"artist"}, spotify.SearchOptions{Limit: 50}).
+					Return(map[string]*spotify.SearchPage{
+						"artist": {Items: []domain.Item{twice}, Total: 1},
+					}, nil).
+					Once()
+				cache.On("MSet", mock.Anything, map[string][]byte{
+					"spotify:v2:g0:suggest:artist::tw": []byte(`{"suggestions":[{"type":"artist","id":"` + twiceID + `","name":"TWICE","image":"https://i.scdn.co/160"}],"complete":true}`),
+				}, time.Hour).
+					Return(nil).
+					Once()
+			},
+			expected: []string{"TWICE"},
+		},
+		{
+			name:  "complete prefix is filtered",
+			query: "twi",
+			types: []string{"artist"},
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("MGet", mock.Anything, []string{"spotify:v2:g0:suggest:artist::twi", "spotify:v2:g0:suggest:artist::tw", "spotify:v2:g0:suggest:artist::t"}).
+					Return([]string{"", suggestionSet(t, true, "Twenty One Pilots", "TWICE", "Two Door Cinema Club", "The Twins"), ""}, nil).
+					Once()
+			},
+			expected: []string{"TWICE", "The Twins"},
+		},
+		{
+			name:  "incomplete prefix with enough matches",
+			query: "the t",
+			types: []string{"artist"},
+			limit: 2,
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("MGet", mock.Anything, mock.Anything).
+					Return([]string{"", suggestionSet(t, false, "The Weeknd", "The Twins", "The Temptations"), "", "", ""}, nil).
+					Once()
+			},
+			expected: []string{"The Twins", "The Temptations"},
+		},
+		{
+			name:  "incomplete prefix without enough matches",
+			query: "the t",
+			types: []string{"artist"},
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("MGet", mock.Anything, mock.Anything).
+					Return([]string{"", suggestionSet(t, false, "The Weeknd", "The Twins"), "", "", ""}, nil).
+					Once()
+				client.On("Search", mock.Anything, "the t", []string{"artist"}, mock.Anything).
+					Return(map[string]*spotify.SearchPage{"artist": {Items: []domain.Item{twice}, Total: 100}}, nil).
+					Once()
+				cache.On("MSet", mock.Anything, mock.Anything, time.Hour).
+					Return(nil).
+					Once()
+			},
+			expected: []string{"TWICE"},
+		},
+		{
+			name:  "exact prefix is not filtered",
+			query: "tw",
+			types: []string{"artist"},
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("MGet", mock.Anything, mock.Anything).
+					Return([]string{suggestionSet(t, false, "TWICE", "Ed Sheeran"), ""}, nil).
+					Once()
+			},
+			expected: []string{"TWICE", "Ed Sheeran"},
+		},
+		{
+			name:  "several types",
+			query: "t",
+			types: []string{"track", "artist", "track"},
+			setup: func(client *mocks.MockSpotifyClient, cache *mocks.MockCache) {
+				cache.On("MGet", mock.Anything, []string{"spotify:v2:g0:suggest:track::t", "spotify:v2:g0:suggest:artist::t"}).
+					Return([]string{"", suggestionSet(t, true, "TWICE")}, nil).
+					Once()
+				client.On("Search", mock.Anything, "t", []string{"track"}, mock.Anything).
+					Return(map[string]*spotify.SearchPage{"track": {Items: []domain.Item{newItemWithID(t, "track", fancyID, "FANCY")}}}, nil).
+					Once()
+				cache.On("MSet", mock.Anything, mock.Anything, time.Hour).
+					Return(nil).
+					Once()
+			},
+			expected: []string{"FANCY", "TWICE"},
+		},
+		{
+			name:        "invalid type",
+			query:       "tw",
+			types:       []string{"concert"},
+			expectedErr: spotify.ErrInvalidQueryType,
+		},
+		{
+			name:        "empty query",
+			query:       "  ",
+			types:       []string{"artist"},
+			expectedErr: spotify.ErrInvalidQuery,
+		},
+		{
+			name:        "limit too high",
+			query:       "tw",
+			types:       []string{"artist"},
+			limit:       21,
+			expectedErr: spotify.ErrInvalidPagination,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedSpotifyClient := &mocks.MockSpotifyClient{}
+			mockedCache := &mocks.MockCache{}
+			t.Cleanup(func() {
+				mockedCache.AssertExpectations(t)
+				mockedSpotifyClient.AssertExpectations(t)
+			})
+
+			s := spotify.New(otel.Tracer("test"), mockedSpotifyClient, mockedCache, spotify.Config{})
+
+			if tt.setup != nil {
+				tt.setup(mockedSpotifyClient, mockedCache)
+			}
+
+			suggestions, err := s.Suggest(context.Background(), tt.query, tt.types, spotify.SuggestOptions{Limit: tt.limit})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			names := []string{}
+			for _, suggestion := range suggestions {
+				names = append(names, suggestion.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestSpotifySearchService_Suggest_Debounce(t *testing.T) {
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+	t.Cleanup(func() {
+		mockedCache.AssertExpectations(t)
+		mockedSpotifyClient.AssertExpectations(t)
+	})
+
+	s := spotify.New(otel.Tracer("test"), mockedSpotifyClient, mockedCache, spotify.Config{SuggestDebounce: 100 * time.Millisecond})
+
+	mockedCache.On("MGet", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, keys []string) []string { return make([]string, len(keys)) }, nil)
+	mockedSpotifyClient.On("Search", mock.Anything, "twi", []string{"artist"}, mock.Anything).
+		Return(map[string]*spotify.SearchPage{"artist": {}}, nil).
+		Once()
+	mockedSpotifyClient.On("Search", mock.Anything, "t", []string{"artist"}, mock.Anything).
+		Return(map[string]*spotify.SearchPage{"artist": {}}, nil).
+		Once()
+	mockedCache.On("MSet", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Twice()
+
+	superseded := make(chan error)
+	go func() {
+		_, err := s.Suggest(context.Background(), "tw", []string{"artist"}, spotify.SuggestOptions{Client: "tab-1"})
+		superseded <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+
+	other := make(chan error)
+	go func() {
+		// Other clients aren't affected
+		_, err := s.Suggest(context.Background(), "t", []string{"artist"}, spotify.SuggestOptions{Client: "tab-2"})
+		other <- err
+	}()
+
+	_, err := s.Suggest(context.Background(), "twi", []string{"artist"}, spotify.SuggestOptions{Client: "tab-1"})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, <-superseded, spotify.ErrSuperseded)
+	assert.NoError(t, <-other)
+}
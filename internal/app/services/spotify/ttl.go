@@ -0,0 +1,177 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// DefaultSearchTTL is the cache TTL of search results of the types that
+// TTLPolicy.Search doesn't set.
+const DefaultSearchTTL = time.Hour * 24
+
+// resourceCacheTTLs are the default cache TTLs of the resources other than
+// searches and items fetched by ID, which TTLPolicy.Resource can change.
+var resourceCacheTTLs = map[string]time.Duration{
+	// Related artists change the least of the artist details
+	"top-tracks":      time.Hour * 24,
+	"artist-albums":   time.Hour * 24,
+	"related-artists": time.Hour * 24 * 7,
+	// Tracklists don't change once an album is released
+	"album-tracks": time.Hour * 24 * 7,
+	// The releases of an ISRC or a UPC hardly ever change
+	"identifier": time.Hour * 24 * 30,
+	// Short links never change, so their target is cached for long
+	"short-link": time.Hour * 24 * 30,
+	// Suggestions are only reused for a short while, as Spotify's ranking
+	// of popular items changes quickly
+	"suggest": time.Hour,
+}
+
+// minTTL is the shortest TTL results are cached for, as caches keep the
+// values with a zero or negative TTL forever.
+const minTTL = time.Second
+
+// jitterSteps is the number of distinct TTLs the values of a batch are
+// spread over, which bounds the number of MSet calls caching them.
+const jitterSteps = 8
+
+// TTLPolicy is how long results are cached. Zero or missing TTLs use the
+// defaults.
+type TTLPolicy struct {
+	// Search is the TTL of search results, per search type.
+	Search map[string]time.Duration
+	// ID is the TTL of the items fetched by ID, per item type.
+	ID map[string]time.Duration
+	// Resource is the TTL of the other resources: top-tracks,
+	// artist-albums, related-artists, album-tracks, identifier (ISRC and
+	// UPC lookups), short-link and suggest.
+	Resource map[string]time.Duration
+	// Negative is the TTL of not-found outcomes, DefaultNegativeCacheTTL
+	// when zero.
+	Negative time.Duration
+	// Jitter randomly shortens or lengthens every TTL by up to this fraction,
+	// such as 0.1 for 10%, so that entries cached together don't all expire
+	// together. It must be in [0, 1).
+	Jitter float64
+	// Max caps every TTL, after jitter. Zero disables it.
+	Max time.Duration
+	// Stale is how long search results and other fetched resources are
+	// still served once their TTL expired, while they are refreshed in the
+	// background. It is added after Max. Zero disables
+	// stale-while-revalidate.
+	Stale time.Duration
+}
+
+// Validate checks that the jitter of the policy is in [0, 1), that its TTLs
+// aren't negative, and that it only sets the TTLs of known resources.
+func (p TTLPolicy) Validate() error {
+	if math.IsNaN(p.Jitter) || p.Jitter < 0 || p.Jitter >= 1 {
+		return fmt.Errorf("%w: jitter must be in [0, 1), got %v", ErrInvalidTTLPolicy, p.Jitter)
+	}
+
+	for resource := range p.Resource {
+		if _, ok := resourceCacheTTLs[resource]; !ok {
+			return fmt.Errorf("%w: unknown resource %q", ErrInvalidTTLPolicy, resource)
+		}
+	}
+
+	for _, ttls := range []map[string]time.Duration{p.Search, p.ID, p.Resource} {
+		for name, ttl := range ttls {
+			if ttl < 0 {
+				return fmt.Errorf("%w: negative TTL for %s", ErrInvalidTTLPolicy, name)
+			}
+		}
+	}
+	if p.Negative < 0 || p.Max < 0 || p.Stale < 0 {
+		return fmt.Errorf("%w: negative TTL", ErrInvalidTTLPolicy)
+	}
+	return nil
+}
+
+// searchTTL returns the TTL of search results of a type, before jitter.
+func (s SpotifySearchService) searchTTL(searchType string) time.Duration {
+	if ttl := s.config.TTLs.Search[searchType]; ttl > 0 {
+		return ttl
+	}
+	return DefaultSearchTTL
+}
+
+// idTTL returns the TTL of the items of a type fetched by ID, before jitter.
+// Types without a default use DefaultSearchTTL.
+func (s SpotifySearchService) idTTL(itemType string) time.Duration {
+	if ttl := s.config.TTLs.ID[itemType]; ttl > 0 {
+		return ttl
+	}
+	if ttl, ok := idCacheTTLs[itemType]; ok {
+		return ttl
+	}
+	return DefaultSearchTTL
+}
+
+// resourceTTL returns the TTL of a resource other than searches and items
+// fetched by ID, before jitter.
+func (s SpotifySearchService) resourceTTL(resource string) time.Duration {
+	if ttl := s.config.TTLs.Resource[resource]; ttl > 0 {
+		return ttl
+	}
+	return resourceCacheTTLs[resource]
+}
+
+// negativeTTL returns the TTL of not-found outcomes, before jitter.
+func (s SpotifySearchService) negativeTTL() time.Duration {
+	if s.config.TTLs.Negative > 0 {
+		return s.config.TTLs.Negative
+	}
+	return DefaultNegativeCacheTTL
+}
+
+// ttl applies a random jitter and the maximum of the policy to a TTL.
+func (s SpotifySearchService) ttl(ttl time.Duration) time.Duration {
+	return s.jitterTTL(ttl, rand.Float64())
+}
+
+// jitterTTL applies the jitter of the policy, from -Jitter to +Jitter as r
+// goes from 0 to 1, and its maximum to a TTL. The result is at least minTTL.
+func (s SpotifySearchService) jitterTTL(ttl time.Duration, r float64) time.Duration {
+	policy := s.config.TTLs
+
+	if policy.Jitter > 0 {
+		ttl += time.Duration((r*2 - 1) * policy.Jitter * float64(ttl))
+	}
+	if policy.Max > 0 && ttl > policy.Max {
+		ttl = policy.Max
+	}
+	return max(ttl, minTTL)
+}
+
+// msetJittered caches a batch of values, each with its own jittered TTL so
+// that they don't all expire together. With stale, the values are wrapped by
+// encodeEntry. The TTLs are drawn from jitterSteps values, and the values
+// are cached with one MSet per TTL.
+func (s SpotifySearchService) msetJittered(ctx context.Context, values map[string][]byte, ttl time.Duration, stale bool) error {
+	batches := map[time.Duration]map[string][]byte{}
+	for key, value := range values {
+		keyTTL := s.jitterTTL(ttl, (float64(rand.Intn(jitterSteps))+0.5)/jitterSteps)
+		if stale {
+			value = s.encodeEntry(value, keyTTL)
+			keyTTL = s.keyTTL(keyTTL)
+		}
+
+		if batches[keyTTL] == nil {
+			batches[keyTTL] = map[string][]byte{}
+		}
+		batches[keyTTL][key] = value
+	}
+
+	var errs []error
+	for keyTTL, batch := range batches {
+		if err := s.cache.MSet(ctx, batch, keyTTL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
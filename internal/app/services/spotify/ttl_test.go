@@ -0,0 +1,260 @@
+package spotify_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifySearchService_TTLPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		ttls       spotify.TTLPolicy
+		searchType string
+		noResults  bool
+		minTTL     time.Duration
+		maxTTL     time.Duration
+	}{
+		{
+			name:       "default",
+			searchType: "artist",
+			minTTL:     spotify.DefaultSearchTTL,
+			maxTTL:     spotify.DefaultSearchTTL,
+		},
+		{
+			name:       "per type",
+			ttls:       spotify.TTLPolicy{Search: map[string]time.Duration{"track": time.Hour * 24 * 7}},
+			searchType: "track",
+			minTTL:     time.Hour * 24 * 7,
+			maxTTL:     time.Hour * 24 * 7,
+		},
+		{
+			name:       "other type",
+			ttls:       spotify.TTLPolicy{Search: map[string]time.Duration{"track": time.Hour * 24 * 7}},
+			searchType: "artist",
+			minTTL:     spotify.DefaultSearchTTL,
+			maxTTL:     spotify.DefaultSearchTTL,
+		},
+		{
+			name:       "negative",
+			ttls:       spotify.TTLPolicy{Negative: time.Minute},
+			searchType: "artist",
+			noResults:  true,
+			minTTL:     time.Minute,
+			maxTTL:     time.Minute,
+		},
+		{
+			name:       "jitter",
+			ttls:       spotify.TTLPolicy{Jitter: 0.1},
+			searchType: "artist",
+			minTTL:     spotify.DefaultSearchTTL * 9 / 10,
+			maxTTL:     spotify.DefaultSearchTTL * 11 / 10,
+		},
+		{
+			name:       "minimum",
+			ttls:       spotify.TTLPolicy{Negative: time.Nanosecond, Jitter: 0.5},
+			searchType: "artist",
+			noResults:  true,
+			minTTL:     time.Second,
+			maxTTL:     time.Second,
+		},
+		{
+			name:       "max",
+			ttls:       spotify.TTLPolicy{Jitter: 0.5, Max: time.Hour * 12},
+			searchType: "artist",
+			minTTL:     time.Hour * 12,
+			maxTTL:     time.Hour * 12,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedSpotifyClient := &mocks.MockSpotifyClient{}
+			mockedCache := &mocks.MockCache{}
+			t.Cleanup(func() {
+				mockedCache.AssertExpectations(t)
+				mockedSpotifyClient.AssertExpectations(t)
+			})
+
+			s := spotify.New(
+				otel.Tracer("test"),
+				mockedSpotifyClient,
+				mockedCache,
+				spotify.Config{TTLs: tt.ttls},
+			)
+
+			opts := spotify.SearchOptions{Limit: 10}
+			key := "spotify:v2:g0:" + tt.searchType + ":::10:0:twice"
+
+			var items []domain.Item
+			if !tt.noResults {
+				items = []domain.Item{newItem(t, tt.searchType, "TWICE")}
+			}
+
+			mockedCache.On("Get", mock.Anything, key).
+				Return("", spotify.ErrCacheMiss).
+				Once()
+			mockedSpotifyClient.On("Search", mock.Anything, "TWICE", []string{tt.searchType}, opts).
+				Return(map[string]*spotify.SearchPage{
+					tt.searchType: spotify.NewSearchPage(items, len(items), opts),
+				}, nil).
+				Once()
+			mockedCache.On("Set", mock.Anything, key, mock.Anything, mock.MatchedBy(func(ttl time.Duration) bool {
+				return ttl >= tt.minTTL && ttl <= tt.maxTTL
+			})).
+				Return(nil).
+				Once()
+
+			_, err := s.SearchPage(context.Background(), "TWICE", tt.searchType, opts)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("id", func(t *testing.T) {
+		mockedSpotifyClient := &mocks.MockSpotifyClient{}
+		mockedCache := &mocks.MockCache{}
+		t.Cleanup(func() {
+			mockedCache.AssertExpectations(t)
+			mockedSpotifyClient.AssertExpectations(t)
+		})
+
+		s := spotify.New(
+			otel.Tracer("test"),
+			mockedSpotifyClient,
+			mockedCache,
+			spotify.Config{TTLs: spotify.TTLPolicy{ID: map[string]time.Duration{"artist": time.Hour * 6}}},
+		)
+
+		mockedCache.On("MGet", mock.Anything, []string{"spotify:v2:g0:id:artist::" + twiceID}).
+			Return([]string{""}, nil).
+			Once()
+		mockedSpotifyClient.On("GetArtists", mock.Anything, []string{twiceID}).
+			Return([]domain.Item{newItemWithID(t, "artist", twiceID, "TWICE")}, nil).
+			Once()
+		mockedCache.On("MSet", mock.Anything, mock.Anything, time.Hour*6).
+			Return(nil).
+			Once()
+
+		item, err := s.GetByID(context.Background(), "artist", twiceID, "")
+		require.NoError(t, err)
+		assert.Equal(t, "TWICE", item.ObjectName())
+	})
+	t.Run("resource", func(t *testing.T) {
+		mockedSpotifyClient := &mocks.MockSpotifyClient{}
+		mockedCache := &mocks.MockCache{}
+		t.Cleanup(func() {
+			mockedCache.AssertExpectations(t)
+			mockedSpotifyClient.AssertExpectations(t)
+		})
+
+		s := spotify.New(
+			otel.Tracer("test"),
+			mockedSpotifyClient,
+			mockedCache,
+			spotify.Config{TTLs: spotify.TTLPolicy{Resource: map[string]time.Duration{"related-artists": time.Hour}}},
+		)
+
+		key := "spotify:v2:g0:artist-related:" + twiceID
+		mockedCache.On("Get", mock.Anything, key).
+			Return("", spotify.ErrCacheMiss).
+			Once()
+		mockedSpotifyClient.On("GetRelatedArtists", mock.Anything, twiceID).
+			Return([]domain.Item{newItem(t, "artist", "ITZY")}, nil).
+			Once()
+		mockedCache.On("Set", mock.Anything, key, mock.Anything, time.Hour).
+			Return(nil).
+			Once()
+
+		artists, err := s.RelatedArtists(context.Background(), twiceID)
+		require.NoError(t, err)
+		assert.Equal(t, "ITZY", artists[0].ObjectName())
+	})
+
+	t.Run("jitter per id", func(t *testing.T) {
+		mockedSpotifyClient := &mocks.MockSpotifyClient{}
+		mockedCache := &mocks.MockCache{}
+		t.Cleanup(func() {
+			mockedCache.AssertExpectations(t)
+			mockedSpotifyClient.AssertExpectations(t)
+		})
+
+		s := spotify.New(
+			otel.Tracer("test"),
+			mockedSpotifyClient,
+			mockedCache,
+			spotify.Config{TTLs: spotify.TTLPolicy{ID: map[string]time.Duration{"artist": time.Hour * 6}, Jitter: 0.5}},
+		)
+
+		var ids []string
+		var artists []domain.Item
+		for i := 0; i < 20; i++ {
+			id := fmt.Sprintf("%022d", i)
+			ids = append(ids, id)
+			artists = append(artists, newItemWithID(t, "artist", id, "TWICE"))
+		}
+
+		mockedCache.On("MGet", mock.Anything, mock.Anything).
+			Return(make([]string, len(ids)), nil).
+			Once()
+		mockedSpotifyClient.On("GetArtists", mock.Anything, ids).
+			Return(artists, nil).
+			Once()
+
+		cached := 0
+		ttls := map[time.Duration]bool{}
+		mockedCache.On("MSet", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				ttl := args.Get(2).(time.Duration)
+				assert.GreaterOrEqual(t, ttl, time.Hour*3)
+				assert.LessOrEqual(t, ttl, time.Hour*9)
+				assert.False(t, ttls[ttl], "one MSet per TTL")
+				ttls[ttl] = true
+				cached += len(args.Get(1).(map[string][]byte))
+			}).
+			Return(nil)
+
+		_, err := s.GetByIDs(context.Background(), "artist", ids, "")
+		require.NoError(t, err)
+		assert.Equal(t, len(ids), cached)
+		assert.Greater(t, len(ttls), 1)
+	})
+}
+
+func TestTTLPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		jitter      float64
+		resource    map[string]time.Duration
+		expectedErr error
+	}{
+		{name: "no jitter"},
+		{name: "jitter", jitter: 0.5},
+		{name: "whole ttl", jitter: 1, expectedErr: spotify.ErrInvalidTTLPolicy},
+		{name: "negative", jitter: -0.1, expectedErr: spotify.ErrInvalidTTLPolicy},
+		{name: "NaN", jitter: math.NaN(), expectedErr: spotify.ErrInvalidTTLPolicy},
+		{name: "resource", resource: map[string]time.Duration{"related-artists": time.Hour}},
+		{name: "unknown resource", resource: map[string]time.Duration{"playlist": time.Hour}, expectedErr: spotify.ErrInvalidTTLPolicy},
+		{name: "negative resource TTL", resource: map[string]time.Duration{"suggest": -time.Hour}, expectedErr: spotify.ErrInvalidTTLPolicy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := spotify.TTLPolicy{Jitter: tt.jitter, Resource: tt.resource}.Validate()
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
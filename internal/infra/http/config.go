@@ -1,7 +1,13 @@
 package server
 
+import "net"
+
 type Config struct {
-	Port              string
+	Port string
+	// TrustedNetworks are the networks whose clients can bypass the cached
+	// not-found outcomes with Cache-Control: no-cache, and use the /admin
+	// routes.
+	TrustedNetworks   []*net.IPNet
 	disableMiddleware bool
 }
 
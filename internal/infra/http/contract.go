@@ -6,4 +6,22 @@ import (
 
 type SpotifyHandler interface {
 	Search(ctx *gin.Context)
+	BatchSearch(ctx *gin.Context)
+	Suggest(ctx *gin.Context)
+	Lookup(ctx *gin.Context)
+	LookupISRC(ctx *gin.Context)
+	LookupUPC(ctx *gin.Context)
+	Artist(ctx *gin.Context)
+	Album(ctx *gin.Context)
+	Track(ctx *gin.Context)
+	AlbumTracks(ctx *gin.Context)
+	ArtistTopTracks(ctx *gin.Context)
+	ArtistAlbums(ctx *gin.Context)
+	RelatedArtists(ctx *gin.Context)
+	ArtistProfile(ctx *gin.Context)
+	Artists(ctx *gin.Context)
+	Albums(ctx *gin.Context)
+	Tracks(ctx *gin.Context)
+	Resolve(ctx *gin.Context)
+	BumpCacheGeneration(ctx *gin.Context)
 }
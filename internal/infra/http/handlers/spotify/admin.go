@@ -0,0 +1,22 @@
+package spotify
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BumpCacheGeneration invalidates all the cached results at once, and
+// responds with the new generation of the cache keys: {"generation": 2}
+func (h *SpotifyHandler) BumpCacheGeneration(c *gin.Context) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.BumpCacheGeneration")
+	defer span.End()
+
+	generation, err := h.spotifySearchService.BumpGeneration(ctx)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"generation": generation})
+}
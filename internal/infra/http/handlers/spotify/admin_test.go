@@ -0,0 +1,68 @@
+package spotify_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifyHandler_BumpCacheGeneration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		setup          func(mockService *mocks.MockSpotifyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "bumped",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("BumpGeneration", mock.Anything).
+					Return(int64(2), nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"generation": 2}`,
+		},
+		{
+			name: "cache error",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("BumpGeneration", mock.Anything).
+					Return(int64(0), errors.New("connection refused")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error": "internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/admin/cache/generation", nil)
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+			tt.setup(mockService)
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.BumpCacheGeneration(ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
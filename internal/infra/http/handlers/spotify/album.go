@@ -0,0 +1,32 @@
+package spotify
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlbumTracks responds with the whole tracklist of an album:
+// {"tracks": [...], "total": 60, "truncated": false}
+func (h *SpotifyHandler) AlbumTracks(c *gin.Context) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.AlbumTracks")
+	defer span.End()
+
+	fields, validFields, err := parseFields(c.Query("fields"), []string{"track"})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_fields": validFields})
+		return
+	}
+
+	tracklist, err := h.spotifySearchService.AlbumTracks(ctx, c.Param("id"), regionalOptions(c).Market)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if fields != nil {
+		fields = fieldTree{"tracks": fields, "total": nil, "truncated": nil}
+	}
+
+	respond(c, tracklist, fields)
+}
@@ -0,0 +1,85 @@
+package spotify_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifyHandler_AlbumTracks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const albumID = "3NZ94nQbqimcu2VCKgXOfW"
+	fancy := &domain.Track{Object: domain.Object{ID: "2pWnuwM6fPpsk9kTPxkDpM", Type: "track", Name: "FANCY"}, TrackNumber: 1}
+
+	tests := []struct {
+		name           string
+		path           string
+		setup          func(mockService *mocks.MockSpotifyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "tracklist",
+			path: "/albums/" + albumID + "/tracks?market=KR&fields=name,track_number",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("AlbumTracks", mock.Anything, albumID, "KR").
+					Return(&appspotify.Tracklist{Tracks: []domain.Item{fancy}, Total: 120, Truncated: true}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"tracks": [{"name": "FANCY", "track_number": 1}], "total": 120, "truncated": true}`,
+		},
+		{
+			name:           "invalid fields",
+			path:           "/albums/" + albumID + "/tracks?fields=label",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown album",
+			path: "/albums/" + albumID + "/tracks",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("AlbumTracks", mock.Anything, albumID, "").
+					Return(nil, appspotify.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, tt.path, nil)
+			ctx.Params = gin.Params{{Key: "id", Value: albumID}}
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+			if tt.setup != nil {
+				tt.setup(mockService)
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.AlbumTracks(ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package spotify
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ArtistTopTracks responds with the top tracks of an artist:
+// {"tracks": [...]}
+func (h *SpotifyHandler) ArtistTopTracks(c *gin.Context) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.ArtistTopTracks")
+	defer span.End()
+
+	fields, validFields, err := parseFields(c.Query("fields"), []string{"track"})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_fields": validFields})
+		return
+	}
+
+	tracks, err := h.spotifySearchService.ArtistTopTracks(ctx, c.Param("id"), regionalOptions(c).Market)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if fields != nil {
+		fields = fieldTree{"tracks": fields}
+	}
+
+	respond(c, map[string]any{"tracks": tracks}, fields)
+}
+
+// ArtistAlbums responds with a page of the albums of an artist, filtered by
+// the comma-separated include_groups parameter.
+func (h *SpotifyHandler) ArtistAlbums(c *gin.Context) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.ArtistAlbums")
+	defer span.End()
+
+	fields, validFields, err := parseFields(c.Query("fields"), []string{"album"})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_fields": validFields})
+		return
+	}
+
+	opts := regionalOptions(c)
+	if limit, ok := c.GetQuery("limit"); ok {
+		opts.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be an integer"})
+			return
+		}
+	}
+	if offset, ok := c.GetQuery("offset"); ok {
+		opts.Offset, err = strconv.Atoi(offset)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be an integer"})
+			return
+		}
+	}
+
+	page, err := h.spotifySearchService.ArtistAlbums(ctx, c.Param("id"), idsParam(c.Query("include_groups")), opts)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	respond(c, page, pageFields(fields))
+}
+
+// RelatedArtists responds with the artists similar to an artist:
+// {"artists": [...]}
+func (h *SpotifyHandler) RelatedArtists(c *gin.Context) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.RelatedArtists")
+	defer span.End()
+
+	fields, validFields, err := parseFields(c.Query("fields"), []string{"artist"})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_fields": validFields})
+		return
+	}
+
+	artists, err := h.spotifySearchService.RelatedArtists(ctx, c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if fields != nil {
+		fields = fieldTree{"artists": fields}
+	}
+
+	respond(c, map[string]any{"artists": artists}, fields)
+}
+
+// ArtistProfile responds with an artist, its top tracks, albums and related
+// artists. Parts that couldn't be fetched are null and their error is listed
+// under "errors".
+func (h *SpotifyHandler) ArtistProfile(c *gin.Context) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.ArtistProfile")
+	defer span.End()
+
+	profile, err := h.spotifySearchService.ArtistProfile(ctx, c.Param("id"), regionalOptions(c).Market)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	response := gin.H{
+		"artist":     profile.Artist,
+		"top_tracks": profile.TopTracks,
+		"albums":     profile.Albums,
+		"related":    profile.Related,
+	}
+	if len(profile.Errors) > 0 {
+		errs := make(map[string]string, len(profile.Errors))
+		for part, err := range profile.Errors {
+			_, errs[part] = errorStatus(err)
+		}
+		response["errors"] = errs
+	}
+
+	c.JSON(http.StatusOK, response)
+}
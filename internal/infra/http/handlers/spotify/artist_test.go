@@ -0,0 +1,187 @@
+package spotify_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifyHandler_Artist(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const twiceID = "7n2Ycct7Beij7Dj7meI4X0"
+	twice := &domain.Artist{Object: domain.Object{ID: twiceID, Type: "artist", Name: "TWICE"}}
+	ive := &domain.Artist{Object: domain.Object{ID: "6RHTUrRF63xao58xh9FXYJ", Type: "artist", Name: "IVE"}}
+	fancy := &domain.Track{Object: domain.Object{ID: "2pWnuwM6fPpsk9kTPxkDpM", Type: "track", Name: "FANCY"}}
+	fancyYou := &domain.Album{SimpleAlbum: domain.SimpleAlbum{Object: domain.Object{ID: "3NZ94nQbqimcu2VCKgXOfW", Type: "album", Name: "FANCY YOU"}}}
+
+	tests := []struct {
+		name           string
+		path           string
+		handle         func(h *handler.SpotifyHandler, c *gin.Context)
+		setup          func(mockService *mocks.MockSpotifyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "top tracks",
+			path:   "/artists/" + twiceID + "/top-tracks?market=KR&fields=name",
+			handle: (*handler.SpotifyHandler).ArtistTopTracks,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("ArtistTopTracks", mock.Anything, twiceID, "KR").
+					Return([]domain.Item{fancy}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"tracks": [{"name": "FANCY"}]}`,
+		},
+		{
+			name:   "albums",
+			path:   "/artists/" + twiceID + "/albums?include_groups=album,single&limit=1&offset=2&fields=name",
+			handle: (*handler.SpotifyHandler).ArtistAlbums,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("ArtistAlbums", mock.Anything, twiceID, []string{"album", "single"}, appspotify.SearchOptions{Limit: 1, Offset: 2}).
+					Return(&appspotify.SearchPage{Items: []domain.Item{fancyYou}, Limit: 1, Offset: 2, Total: 3}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"items": [{"name": "FANCY YOU"}], "limit": 1, "offset": 2, "total": 3, "next": null, "previous": null}`,
+		},
+		{
+			name:           "albums with an invalid limit",
+			path:           "/artists/" + twiceID + "/albums?limit=ten",
+			handle:         (*handler.SpotifyHandler).ArtistAlbums,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "limit must be an integer"}`,
+		},
+		{
+			name:   "albums with an unknown group",
+			path:   "/artists/" + twiceID + "/albums?include_groups=ep",
+			handle: (*handler.SpotifyHandler).ArtistAlbums,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("ArtistAlbums", mock.Anything, twiceID, []string{"ep"}, appspotify.SearchOptions{}).
+					Return(nil, fmt.Errorf("%w: unknown group", appspotify.ErrInvalidFilter)).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid filter: unknown group"}`,
+		},
+		{
+			name:   "related",
+			path:   "/artists/" + twiceID + "/related?fields=name",
+			handle: (*handler.SpotifyHandler).RelatedArtists,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("RelatedArtists", mock.Anything, twiceID).
+					Return([]domain.Item{ive}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"artists": [{"name": "IVE"}]}`,
+		},
+		{
+			name:   "related of an unknown artist",
+			path:   "/artists/" + twiceID + "/related",
+			handle: (*handler.SpotifyHandler).RelatedArtists,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("RelatedArtists", mock.Anything, twiceID).
+					Return(nil, appspotify.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "not found"}`,
+		},
+		{
+			name:   "profile of an unknown artist",
+			path:   "/artists/" + twiceID + "/profile",
+			handle: (*handler.SpotifyHandler).ArtistProfile,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("ArtistProfile", mock.Anything, twiceID, "").
+					Return(nil, appspotify.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, tt.path, nil)
+			ctx.Params = gin.Params{{Key: "id", Value: twiceID}}
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+			if tt.setup != nil {
+				tt.setup(mockService)
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			tt.handle(h, ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+
+	t.Run("partial profile", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/artists/"+twiceID+"/profile", nil)
+		ctx.Params = gin.Params{{Key: "id", Value: twiceID}}
+
+		mockService := &mocks.MockSpotifyService{}
+		t.Cleanup(func() {
+			mockService.AssertExpectations(t)
+		})
+		mockService.On("ArtistProfile", mock.Anything, twiceID, "").
+			Return(&appspotify.ArtistProfile{
+				Artist:    twice,
+				TopTracks: []domain.Item{fancy},
+				Errors: map[string]error{
+					"albums":  fmt.Errorf("%w: %s", appspotify.ErrSpotifyClient, errors.New("timeout")),
+					"related": appspotify.ErrNotFound,
+				},
+			}, nil).
+			Once()
+
+		h := handler.New(otel.Tracer("test"), mockService)
+		h.ArtistProfile(ctx)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var body struct {
+			Artist    map[string]any    `json:"artist"`
+			TopTracks []map[string]any  `json:"top_tracks"`
+			Albums    *json.RawMessage  `json:"albums"`
+			Related   *json.RawMessage  `json:"related"`
+			Errors    map[string]string `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+		assert.Equal(t, "TWICE", body.Artist["name"])
+		require.Len(t, body.TopTracks, 1)
+		assert.Equal(t, "FANCY", body.TopTracks[0]["name"])
+		assert.Nil(t, body.Albums)
+		assert.Nil(t, body.Related)
+		assert.Equal(t, map[string]string{"albums": "spotify client error", "related": "not found"}, body.Errors)
+	})
+}
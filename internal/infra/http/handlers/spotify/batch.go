@@ -0,0 +1,144 @@
+package spotify
+
+import (
+	"net/http"
+	"net/url"
+
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/gin-gonic/gin"
+)
+
+type batchItem struct {
+	Type    string           `json:"type"`
+	Query   string           `json:"query"`
+	Options batchItemOptions `json:"options"`
+}
+
+// batchItemOptions are the query parameters of /search, in JSON.
+type batchItemOptions struct {
+	Mode          string  `json:"mode"`
+	Limit         *int    `json:"limit"`
+	Offset        *int    `json:"offset"`
+	Market        string  `json:"market"`
+	Locale        string  `json:"locale"`
+	MinConfidence float64 `json:"min_confidence"`
+	Explain       bool    `json:"explain"`
+
+	Artist string `json:"artist"`
+	Album  string `json:"album"`
+	Track  string `json:"track"`
+	Year   string `json:"year"`
+	Genre  string `json:"genre"`
+	ISRC   string `json:"isrc"`
+	UPC    string `json:"upc"`
+	Tag    string `json:"tag"`
+}
+
+type batchItemResult struct {
+	Status int    `json:"status"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BatchSearch runs several searches from a JSON array of
+// {"type", "query", "options"} items, and responds with the result or error
+// of each of them in the same order.
+func (h *SpotifyHandler) BatchSearch(c *gin.Context) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.BatchSearch")
+	defer span.End()
+
+	var items []batchItem
+	if err := c.ShouldBindJSON(&items); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "body must be an array of {type, query, options}"})
+		return
+	}
+
+	// Market and locale default to the ones of the request
+	defaults := regionalOptions(c)
+
+	requests := make([]appspotify.BatchRequest, 0, len(items))
+	for i, item := range items {
+		request, err := batchRequest(item, defaults)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "index": i})
+			return
+		}
+		requests = append(requests, request)
+	}
+
+	results, err := h.spotifySearchService.BatchSearch(ctx, requests)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	response := make([]batchItemResult, 0, len(results))
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			status, message := errorStatus(result.Err)
+			response = append(response, batchItemResult{Status: status, Error: message})
+		case result.Page != nil:
+			response = append(response, batchItemResult{Status: http.StatusOK, Result: result.Page})
+		default:
+			response = append(response, batchItemResult{Status: http.StatusOK, Result: result.Match})
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func batchRequest(item batchItem, defaults appspotify.SearchOptions) (appspotify.BatchRequest, error) {
+	options := item.Options
+
+	request := appspotify.BatchRequest{
+		Type: item.Type,
+		// The service unescapes queries as they come from the URL of /search,
+		// whereas these are plain text.
+		Query: url.QueryEscape(item.Query),
+		Options: appspotify.SearchOptions{
+			Market: defaults.Market,
+			Locale: defaults.Locale,
+			Filters: appspotify.SearchFilters{
+				Artist: options.Artist,
+				Album:  options.Album,
+				Track:  options.Track,
+				Year:   options.Year,
+				Genre:  options.Genre,
+				ISRC:   options.ISRC,
+				UPC:    options.UPC,
+				Tag:    options.Tag,
+			},
+		},
+		Match: appspotify.MatchOptions{
+			MinConfidence: options.MinConfidence,
+			Explain:       options.Explain,
+		},
+	}
+
+	if options.Market != "" {
+		request.Options.Market = options.Market
+	}
+	if options.Locale != "" {
+		request.Options.Locale = options.Locale
+	}
+	if options.Limit != nil {
+		request.Options.Limit = *options.Limit
+	}
+	if options.Offset != nil {
+		request.Options.Offset = *options.Offset
+	}
+
+	// Same as /search: a single result unless paging options are set
+	switch options.Mode {
+	case "":
+		request.List = options.Limit != nil || options.Offset != nil
+	case searchModeSingle:
+	case searchModeList:
+		request.List = true
+	default:
+		return request, errInvalidMode
+	}
+
+	return request, nil
+}
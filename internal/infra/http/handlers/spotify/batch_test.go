@@ -0,0 +1,126 @@
+package spotify_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifyHandler_BatchSearch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	twice := &domain.Artist{Object: domain.Object{ID: "twice", Type: "artist", Name: "TWICE"}}
+
+	tests := []struct {
+		name             string
+		body             string
+		acceptLanguage   string
+		expectedRequests []appspotify.BatchRequest
+		serviceResults   []appspotify.BatchResult
+		serviceErr       error
+		expectedStatus   int
+		expectedBody     string
+	}{
+		{
+			name: "results and errors",
+			body: `[
+				{"type": "artist", "query": "TWICE", "options": {"min_confidence": 0.5}},
+				{"type": "track", "query": "AC/DC + more", "options": {"limit": 5, "market": "US", "artist": "AC/DC"}},
+				{"type": "album", "query": "nothing"}
+			]`,
+			acceptLanguage: "fr-FR",
+			expectedRequests: []appspotify.BatchRequest{
+				{
+					Type:    "artist",
+					Query:   "TWICE",
+					Options: appspotify.SearchOptions{Market: "FR", Locale: "fr_FR"},
+					Match:   appspotify.MatchOptions{MinConfidence: 0.5},
+				},
+				{
+					Type:  "track",
+					Query: "AC%2FDC+%2B+more",
+					Options: appspotify.SearchOptions{
+						Limit:   5,
+						Market:  "US",
+						Locale:  "fr_FR",
+						Filters: appspotify.SearchFilters{Artist: "AC/DC"},
+					},
+					List: true,
+				},
+				{
+					Type:    "album",
+					Query:   "nothing",
+					Options: appspotify.SearchOptions{Market: "FR", Locale: "fr_FR"},
+				},
+			},
+			serviceResults: []appspotify.BatchResult{
+				{Match: &appspotify.Match{Item: twice, Confidence: 1}},
+				{Page: &appspotify.SearchPage{Items: []domain.Item{}, Limit: 5}},
+				{Err: appspotify.ErrNoResultsFound},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `[
+				{"status": 200, "result": {"id": "twice", "type": "artist", "name": "TWICE", "uri": "", "external_urls": null, "genres": null, "images": null, "popularity": 0, "followers": {"total": 0}, "confidence": 1}},
+				{"status": 200, "result": {"items": [], "total": 0, "limit": 5, "offset": 0, "next": null, "previous": null}},
+				{"status": 404, "error": "no results found"}
+			]`,
+		},
+		{
+			name:           "not an array",
+			body:           `{"type": "artist", "query": "TWICE"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "body must be an array of {type, query, options}"}`,
+		},
+		{
+			name:           "invalid mode",
+			body:           `[{"type": "artist", "query": "TWICE"}, {"type": "artist", "query": "TWICE", "options": {"mode": "all"}}]`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "mode must be single or list", "index": 1}`,
+		},
+		{
+			name:             "empty batch",
+			body:             `[]`,
+			expectedRequests: []appspotify.BatchRequest{},
+			serviceErr:       appspotify.ErrInvalidBatch,
+			expectedStatus:   http.StatusBadRequest,
+			expectedBody:     `{"error": "invalid batch"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/search/batch", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Request.Header.Set("Accept-Language", tt.acceptLanguage)
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+			if tt.expectedRequests != nil {
+				mockService.On("BatchSearch", mock.Anything, tt.expectedRequests).
+					Return(tt.serviceResults, tt.serviceErr).
+					Once()
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.BatchSearch(ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
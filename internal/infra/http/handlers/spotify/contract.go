@@ -1,7 +1,29 @@
 package spotify
 
-import "context"
+import (
+	"context"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+)
 
 type SpotifyService interface {
-	Search(ctx context.Context, query string, searchType string) (any, error)
+	Search(ctx context.Context, query string, searchType string, opts appspotify.SearchOptions, matchOpts appspotify.MatchOptions) (*appspotify.Match, error)
+	BestMatches(ctx context.Context, query string, searchTypes []string, opts appspotify.SearchOptions, matchOpts appspotify.MatchOptions) (map[string]*appspotify.Match, error)
+	SearchPage(ctx context.Context, query string, searchType string, opts appspotify.SearchOptions) (*appspotify.SearchPage, error)
+	MultiSearch(ctx context.Context, query string, searchTypes []string, opts appspotify.SearchOptions) (map[string]*appspotify.SearchPage, error)
+	Suggest(ctx context.Context, query string, searchTypes []string, opts appspotify.SuggestOptions) ([]appspotify.Suggestion, error)
+	BatchSearch(ctx context.Context, requests []appspotify.BatchRequest) ([]appspotify.BatchResult, error)
+	LookupISRC(ctx context.Context, isrc string, market string, prefer string) ([]domain.Item, error)
+	LookupUPC(ctx context.Context, upc string, market string, prefer string) ([]domain.Item, error)
+	GetByID(ctx context.Context, itemType string, id string, market string) (domain.Item, error)
+	GetByIDs(ctx context.Context, itemType string, ids []string, market string) ([]domain.Item, error)
+	Resolve(ctx context.Context, link string, market string) (domain.Item, error)
+	AlbumTracks(ctx context.Context, id string, market string) (*appspotify.Tracklist, error)
+	ArtistTopTracks(ctx context.Context, id string, market string) ([]domain.Item, error)
+	ArtistAlbums(ctx context.Context, id string, includeGroups []string, opts appspotify.SearchOptions) (*appspotify.SearchPage, error)
+	RelatedArtists(ctx context.Context, id string) ([]domain.Item, error)
+	ArtistProfile(ctx context.Context, id string, market string) (*appspotify.ArtistProfile, error)
+	Lookup(ctx context.Context, lookupType string, artist string, name string, opts appspotify.SearchOptions, matchOpts appspotify.MatchOptions) (*appspotify.Match, error)
+	BumpGeneration(ctx context.Context) (int64, error)
 }
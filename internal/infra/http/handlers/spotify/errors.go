@@ -0,0 +1,48 @@
+package spotify
+
+import (
+	"errors"
+	"net/http"
+
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/gin-gonic/gin"
+)
+
+func writeError(c *gin.Context, err error) {
+	status, message := errorStatus(err)
+	c.JSON(status, gin.H{"error": message})
+}
+
+// errorStatus maps service errors to HTTP statuses and messages.
+func errorStatus(err error) (int, string) {
+	status := http.StatusInternalServerError
+	message := "internal server error"
+
+	switch {
+	case errors.Is(err, appspotify.ErrInvalidQueryType):
+		status = http.StatusBadRequest
+		message = "invalid search type"
+	case errors.Is(err, appspotify.ErrInvalidPagination),
+		errors.Is(err, appspotify.ErrInvalidMarket),
+		errors.Is(err, appspotify.ErrInvalidLocale),
+		errors.Is(err, appspotify.ErrInvalidQuery),
+		errors.Is(err, appspotify.ErrInvalidFilter),
+		errors.Is(err, appspotify.ErrInvalidMinConfidence),
+		errors.Is(err, appspotify.ErrInvalidBatch),
+		errors.Is(err, appspotify.ErrInvalidID),
+		errors.Is(err, appspotify.ErrInvalidLink):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, appspotify.ErrNoResultsFound):
+		status = http.StatusNotFound
+		message = "no results found"
+	case errors.Is(err, appspotify.ErrNotFound):
+		status = http.StatusNotFound
+		message = "not found"
+	case errors.Is(err, appspotify.ErrSpotifyClient):
+		status = http.StatusBadGateway
+		message = "spotify client error"
+	}
+
+	return status, message
+}
@@ -0,0 +1,253 @@
+package spotify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidFields = errors.New("invalid fields")
+
+// fieldTree is a set of JSON paths to keep. A nil subtree keeps the whole
+// value.
+type fieldTree map[string]fieldTree
+
+// parseFields parses a fields parameter such as "name,images.url" or
+// "name,album(name,images(url))", and checks that every path exists in the
+// items of at least one of the search types. It returns nil when no fields
+// were requested.
+func parseFields(fields string, searchTypes []string) (fieldTree, []string, error) {
+	if fields == "" {
+		return nil, nil, nil
+	}
+
+	var validPaths []string
+	for _, searchType := range searchTypes {
+		item, err := domain.NewItem(searchType)
+		if err != nil {
+			continue
+		}
+		for _, path := range fieldPaths(reflect.TypeOf(item), "") {
+			if !slices.Contains(validPaths, path) {
+				validPaths = append(validPaths, path)
+			}
+		}
+	}
+	slices.Sort(validPaths)
+
+	paths, rest, err := parseFieldList(fields, "")
+	if err == nil && rest != "" {
+		err = fmt.Errorf("%w: unexpected %q", errInvalidFields, rest)
+	}
+	if err != nil {
+		return nil, validPaths, err
+	}
+
+	tree := fieldTree{}
+	for _, path := range paths {
+		if !slices.Contains(validPaths, path) {
+			return nil, validPaths, fmt.Errorf("%w: unknown field %q", errInvalidFields, path)
+		}
+		tree.add(strings.Split(path, "."))
+	}
+
+	return tree, validPaths, nil
+}
+
+// parseFieldList parses comma-separated fields until the end of the input or
+// a closing parenthesis, and returns the unparsed input.
+func parseFieldList(input string, prefix string) ([]string, string, error) {
+	var paths []string
+
+	for {
+		end := strings.IndexAny(input, ",()")
+		if end == -1 {
+			end = len(input)
+		}
+
+		name := strings.TrimSpace(input[:end])
+		if name == "" {
+			return nil, input, fmt.Errorf("%w: empty field name", errInvalidFields)
+		}
+		input = input[end:]
+
+		if strings.HasPrefix(input, "(") {
+			nested, rest, err := parseFieldList(input[1:], prefix+name+".")
+			if err != nil {
+				return nil, rest, err
+			}
+			if !strings.HasPrefix(rest, ")") {
+				return nil, rest, fmt.Errorf("%w: missing closing parenthesis", errInvalidFields)
+			}
+			paths = append(paths, nested...)
+			input = rest[1:]
+		} else {
+			paths = append(paths, prefix+name)
+		}
+
+		if !strings.HasPrefix(input, ",") {
+			return paths, input, nil
+		}
+		input = input[1:]
+	}
+}
+
+// fieldPaths lists the JSON paths of a type, following its json tags.
+func fieldPaths(t reflect.Type, prefix string) []string {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var paths []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			paths = append(paths, fieldPaths(field.Type, prefix)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		paths = append(paths, prefix+name)
+		paths = append(paths, fieldPaths(field.Type, prefix+name+".")...)
+	}
+	return paths
+}
+
+func (tree fieldTree) add(path []string) {
+	subtree, exists := tree[path[0]]
+	if len(path) == 1 {
+		tree[path[0]] = nil
+		return
+	}
+	if exists && subtree == nil {
+		// The whole value is already kept
+		return
+	}
+	if subtree == nil {
+		subtree = fieldTree{}
+		tree[path[0]] = subtree
+	}
+	subtree.add(path[1:])
+}
+
+// pageFields applies the item fields to the items of a page, and keeps the
+// rest of the page.
+func pageFields(itemFields fieldTree) fieldTree {
+	if itemFields == nil {
+		return nil
+	}
+
+	return fieldTree{
+		"items":    itemFields,
+		"total":    nil,
+		"limit":    nil,
+		"offset":   nil,
+		"next":     nil,
+		"previous": nil,
+	}
+}
+
+// matchFields keeps the confidence and candidates of a match along with the
+// item fields.
+func matchFields(itemFields fieldTree) fieldTree {
+	if itemFields == nil {
+		return nil
+	}
+
+	tree := maps.Clone(itemFields)
+	tree["confidence"] = nil
+	tree["candidates"] = nil
+	return tree
+}
+
+// perType applies the same fields to each type of a multi-type response.
+func perType(fields fieldTree, searchTypes []string) fieldTree {
+	if fields == nil {
+		return nil
+	}
+
+	tree := make(fieldTree, len(searchTypes))
+	for _, searchType := range searchTypes {
+		tree[searchType] = fields
+	}
+	return tree
+}
+
+// respond writes the value as JSON, trimmed to the requested fields if any.
+func respond(c *gin.Context, value any, fields fieldTree) {
+	if fields == nil {
+		c.JSON(http.StatusOK, value)
+		return
+	}
+
+	projected, err := fields.project(value)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, projected)
+}
+
+// project encodes the value to JSON and only keeps the fields of the tree.
+func (tree fieldTree) project(value any) (any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, err
+	}
+
+	return tree.apply(decoded), nil
+}
+
+func (tree fieldTree) apply(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		projected := make(map[string]any, len(tree))
+		for key, subtree := range tree {
+			child, ok := value[key]
+			if !ok {
+				continue
+			}
+			if subtree == nil {
+				projected[key] = child
+			} else {
+				projected[key] = subtree.apply(child)
+			}
+		}
+		return projected
+	case []any:
+		projected := make([]any, 0, len(value))
+		for _, child := range value {
+			projected = append(projected, tree.apply(child))
+		}
+		return projected
+	default:
+		return value
+	}
+}
@@ -0,0 +1,50 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// LookupISRC responds with the tracks of an ISRC, the original release
+// first: {"tracks": [...]}
+func (h *SpotifyHandler) LookupISRC(c *gin.Context) {
+	h.lookupIdentifier(c, "track", c.Param("isrc"), h.spotifySearchService.LookupISRC)
+}
+
+// LookupUPC responds with the albums of a UPC, the original release first:
+// {"albums": [...]}
+func (h *SpotifyHandler) LookupUPC(c *gin.Context) {
+	h.lookupIdentifier(c, "album", c.Param("upc"), h.spotifySearchService.LookupUPC)
+}
+
+func (h *SpotifyHandler) lookupIdentifier(
+	c *gin.Context,
+	itemType string,
+	identifier string,
+	lookup func(ctx context.Context, identifier string, market string, prefer string) ([]domain.Item, error),
+) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.lookupIdentifier")
+	defer span.End()
+
+	fields, validFields, err := parseFields(c.Query("fields"), []string{itemType})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_fields": validFields})
+		return
+	}
+
+	items, err := lookup(ctx, identifier, regionalOptions(c).Market, c.Query("prefer"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	key := itemType + "s"
+	if fields != nil {
+		fields = fieldTree{key: fields}
+	}
+
+	respond(c, map[string]any{key: items}, fields)
+}
@@ -0,0 +1,111 @@
+package spotify_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifyHandler_LookupIdentifier(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	fancy := &domain.Track{Object: domain.Object{ID: "2pWnuwM6fPpsk9kTPxkDpM", Type: "track", Name: "FANCY"}}
+	fancyYou := &domain.Album{SimpleAlbum: domain.SimpleAlbum{Object: domain.Object{ID: "3NZ94nQbqimcu2VCKgXOfW", Type: "album", Name: "FANCY YOU"}}}
+
+	tests := []struct {
+		name           string
+		path           string
+		params         gin.Params
+		handle         func(h *handler.SpotifyHandler, c *gin.Context)
+		setup          func(mockService *mocks.MockSpotifyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "isrc",
+			path:   "/lookup/isrc/KRA381901155?market=KR&prefer=clean&fields=name",
+			params: gin.Params{{Key: "isrc", Value: "KRA381901155"}},
+			handle: (*handler.SpotifyHandler).LookupISRC,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("LookupISRC", mock.Anything, "KRA381901155", "KR", "clean").
+					Return([]domain.Item{fancy}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"tracks": [{"name": "FANCY"}]}`,
+		},
+		{
+			name:   "upc",
+			path:   "/lookup/upc/8804775130032?fields=name",
+			params: gin.Params{{Key: "upc", Value: "8804775130032"}},
+			handle: (*handler.SpotifyHandler).LookupUPC,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("LookupUPC", mock.Anything, "8804775130032", "", "").
+					Return([]domain.Item{fancyYou}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"albums": [{"name": "FANCY YOU"}]}`,
+		},
+		{
+			name:   "invalid isrc",
+			path:   "/lookup/isrc/FANCY",
+			params: gin.Params{{Key: "isrc", Value: "FANCY"}},
+			handle: (*handler.SpotifyHandler).LookupISRC,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("LookupISRC", mock.Anything, "FANCY", "", "").
+					Return(nil, fmt.Errorf("%w: invalid isrc %q", appspotify.ErrInvalidID, "FANCY")).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid id: invalid isrc \"FANCY\""}`,
+		},
+		{
+			name:   "unknown upc",
+			path:   "/lookup/upc/036000291452",
+			params: gin.Params{{Key: "upc", Value: "036000291452"}},
+			handle: (*handler.SpotifyHandler).LookupUPC,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("LookupUPC", mock.Anything, "036000291452", "", "").
+					Return(nil, appspotify.ErrNoResultsFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "no results found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, tt.path, nil)
+			ctx.Params = tt.params
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+			if tt.setup != nil {
+				tt.setup(mockService)
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			tt.handle(h, ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package spotify
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *SpotifyHandler) Artist(c *gin.Context) {
+	h.getByID(c, "artist")
+}
+
+func (h *SpotifyHandler) Album(c *gin.Context) {
+	h.getByID(c, "album")
+}
+
+func (h *SpotifyHandler) Track(c *gin.Context) {
+	h.getByID(c, "track")
+}
+
+func (h *SpotifyHandler) Artists(c *gin.Context) {
+	h.getByIDs(c, "artist")
+}
+
+func (h *SpotifyHandler) Albums(c *gin.Context) {
+	h.getByIDs(c, "album")
+}
+
+func (h *SpotifyHandler) Tracks(c *gin.Context) {
+	h.getByIDs(c, "track")
+}
+
+// getByID responds with the item of the given type and ID.
+func (h *SpotifyHandler) getByID(c *gin.Context, itemType string) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.getByID")
+	defer span.End()
+
+	fields, validFields, err := parseFields(c.Query("fields"), []string{itemType})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_fields": validFields})
+		return
+	}
+
+	item, err := h.spotifySearchService.GetByID(ctx, itemType, c.Param("id"), regionalOptions(c).Market)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	respond(c, item, fields)
+}
+
+// getByIDs responds with the items of the comma-separated ids parameter, in
+// the same order and with null for unknown IDs, as Spotify does:
+// {"artists": [...]}
+func (h *SpotifyHandler) getByIDs(c *gin.Context, itemType string) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.getByIDs")
+	defer span.End()
+
+	ids := idsParam(c.Query("ids"))
+	if len(ids) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids is required"})
+		return
+	}
+
+	fields, validFields, err := parseFields(c.Query("fields"), []string{itemType})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_fields": validFields})
+		return
+	}
+
+	items, err := h.spotifySearchService.GetByIDs(ctx, itemType, ids, regionalOptions(c).Market)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	key := itemType + "s"
+	if fields != nil {
+		fields = fieldTree{key: fields}
+	}
+
+	respond(c, map[string]any{key: items}, fields)
+}
+
+// idsParam splits a comma-separated list of IDs.
+func idsParam(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
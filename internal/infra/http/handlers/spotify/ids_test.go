@@ -0,0 +1,131 @@
+package spotify_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifyHandler_GetByID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	twice := &domain.Artist{Object: domain.Object{ID: "7n2Ycct7Beij7Dj7meI4X0", Type: "artist", Name: "TWICE"}}
+	fancy := &domain.Track{Object: domain.Object{ID: "2pWnuwM6fPpsk9kTPxkDpM", Type: "track", Name: "FANCY"}}
+
+	tests := []struct {
+		name           string
+		path           string
+		id             string
+		handle         func(h *handler.SpotifyHandler, c *gin.Context)
+		setup          func(mockService *mocks.MockSpotifyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "artist",
+			path:   "/artists/7n2Ycct7Beij7Dj7meI4X0?fields=id,name",
+			id:     "7n2Ycct7Beij7Dj7meI4X0",
+			handle: (*handler.SpotifyHandler).Artist,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("GetByID", mock.Anything, "artist", "7n2Ycct7Beij7Dj7meI4X0", "").
+					Return(twice, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id": "7n2Ycct7Beij7Dj7meI4X0", "name": "TWICE"}`,
+		},
+		{
+			name:   "track in a market",
+			path:   "/tracks/2pWnuwM6fPpsk9kTPxkDpM?market=KR&fields=name",
+			id:     "2pWnuwM6fPpsk9kTPxkDpM",
+			handle: (*handler.SpotifyHandler).Track,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("GetByID", mock.Anything, "track", "2pWnuwM6fPpsk9kTPxkDpM", "KR").
+					Return(fancy, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name": "FANCY"}`,
+		},
+		{
+			name:   "not found",
+			path:   "/albums/2pWnuwM6fPpsk9kTPxkDpM",
+			id:     "2pWnuwM6fPpsk9kTPxkDpM",
+			handle: (*handler.SpotifyHandler).Album,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("GetByID", mock.Anything, "album", "2pWnuwM6fPpsk9kTPxkDpM", "").
+					Return(nil, appspotify.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "not found"}`,
+		},
+		{
+			name:   "invalid id",
+			path:   "/artists/twice",
+			id:     "twice",
+			handle: (*handler.SpotifyHandler).Artist,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("GetByID", mock.Anything, "artist", "twice", "").
+					Return(nil, appspotify.ErrInvalidID).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid id"}`,
+		},
+		{
+			name:   "several ids",
+			path:   "/artists?ids=7n2Ycct7Beij7Dj7meI4X0,2pWnuwM6fPpsk9kTPxkDpM&fields=name",
+			handle: (*handler.SpotifyHandler).Artists,
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("GetByIDs", mock.Anything, "artist", []string{"7n2Ycct7Beij7Dj7meI4X0", "2pWnuwM6fPpsk9kTPxkDpM"}, "").
+					Return([]domain.Item{twice, nil}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"artists": [{"name": "TWICE"}, null]}`,
+		},
+		{
+			name:           "missing ids",
+			path:           "/tracks?ids=,",
+			handle:         (*handler.SpotifyHandler).Tracks,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "ids is required"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.id != "" {
+				ctx.Params = gin.Params{{Key: "id", Value: tt.id}}
+			}
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+			if tt.setup != nil {
+				tt.setup(mockService)
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			tt.handle(h, ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
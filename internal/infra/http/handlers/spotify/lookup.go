@@ -0,0 +1,50 @@
+package spotify
+
+import (
+	"net/http"
+	"slices"
+
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/gin-gonic/gin"
+)
+
+// Lookup finds a track or album from an artist and a name, as found in
+// scrobbles: /lookup/track?artist=...&track=... or
+// /lookup/album?artist=...&album=...
+func (h *SpotifyHandler) Lookup(c *gin.Context) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.Lookup")
+	defer span.End()
+
+	lookupType := c.Param("type")
+	if !slices.Contains(appspotify.LookupTypes, lookupType) {
+		writeError(c, appspotify.ErrInvalidQueryType)
+		return
+	}
+
+	artist := c.Query("artist")
+	name := c.Query(lookupType)
+	if artist == "" || name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "artist and " + lookupType + " are required"})
+		return
+	}
+
+	fields, validFields, err := parseFields(c.Query("fields"), []string{lookupType})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_fields": validFields})
+		return
+	}
+
+	matchOpts, err := matchOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	match, err := h.spotifySearchService.Lookup(ctx, lookupType, artist, name, regionalOptions(c), matchOpts)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	respond(c, match, matchFields(fields))
+}
@@ -0,0 +1,99 @@
+package spotify_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifyHandler_Lookup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	fancy := &domain.Track{
+		Object:  domain.Object{ID: "fancy", Type: "track", Name: "FANCY"},
+		Artists: []domain.SimpleArtist{{Object: domain.Object{Type: "artist", Name: "TWICE"}}},
+	}
+
+	tests := []struct {
+		name           string
+		lookupType     string
+		rawQuery       string
+		setup          func(mockService *mocks.MockSpotifyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:       "track",
+			lookupType: "track",
+			rawQuery:   "artist=TWICE&track=FANCY&market=KR&fields=id,name",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("Lookup", mock.Anything, "track", "TWICE", "FANCY", appspotify.SearchOptions{Market: "KR"}, appspotify.MatchOptions{}).
+					Return(&appspotify.Match{Item: fancy, Confidence: 0.9}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id": "fancy", "name": "FANCY", "confidence": 0.9}`,
+		},
+		{
+			name:       "album with min confidence",
+			lookupType: "album",
+			rawQuery:   "artist=TWICE&album=Eyes+Wide+Open&min_confidence=0.5",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("Lookup", mock.Anything, "album", "TWICE", "Eyes Wide Open", appspotify.SearchOptions{}, appspotify.MatchOptions{MinConfidence: 0.5}).
+					Return(nil, appspotify.ErrNoResultsFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "no results found"}`,
+		},
+		{
+			name:           "missing name",
+			lookupType:     "track",
+			rawQuery:       "artist=TWICE&album=FANCY",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "artist and track are required"}`,
+		},
+		{
+			name:           "invalid type",
+			lookupType:     "artist",
+			rawQuery:       "artist=TWICE",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid search type"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/lookup/"+tt.lookupType+"?"+tt.rawQuery, nil)
+			ctx.Params = gin.Params{
+				{Key: "type", Value: tt.lookupType},
+			}
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+			if tt.setup != nil {
+				tt.setup(mockService)
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.Lookup(ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
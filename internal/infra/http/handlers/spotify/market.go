@@ -0,0 +1,59 @@
+package spotify
+
+import (
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// regionalOptions reads the market and locale from the query parameters,
+// falling back to the Accept-Language header. When neither is set, the
+// service uses its default market.
+func regionalOptions(c *gin.Context) appspotify.SearchOptions {
+	market, locale := marketFromAcceptLanguage(c.GetHeader("Accept-Language"))
+
+	if value := c.Query("market"); value != "" {
+		market = value
+	}
+	if value := c.Query("locale"); value != "" {
+		locale = value
+	}
+
+	return appspotify.SearchOptions{
+		Market: market,
+		Locale: locale,
+	}
+}
+
+// marketFromAcceptLanguage returns the first country found in the header as
+// the market, and the preferred language as a Spotify locale (e.g. fr_FR).
+func marketFromAcceptLanguage(header string) (string, string) {
+	if header == "" {
+		return "", ""
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return "", ""
+	}
+
+	var market string
+	for _, tag := range tags {
+		region, confidence := tag.Region()
+		if confidence == language.Exact && region.IsCountry() {
+			market = region.String()
+			break
+		}
+	}
+
+	var locale string
+	if preferred := tags[0]; preferred != language.Und {
+		base, _ := preferred.Base()
+		locale = base.String()
+		if region, confidence := preferred.Region(); confidence == language.Exact && region.IsCountry() {
+			locale += "_" + region.String()
+		}
+	}
+
+	return market, locale
+}
@@ -5,7 +5,11 @@ package mocks
 import (
 	context "context"
 
+	domain "github.com/angristan/spotify-search-proxy/internal/app/domain"
+
 	mock "github.com/stretchr/testify/mock"
+
+	spotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
 )
 
 // MockSpotifyService is an autogenerated mock type for the SpotifyService type
@@ -21,29 +25,29 @@ func (_m *MockSpotifyService) EXPECT() *MockSpotifyService_Expecter {
 	return &MockSpotifyService_Expecter{mock: &_m.Mock}
 }
 
-// Search provides a mock function with given fields: ctx, query, searchType
-func (_m *MockSpotifyService) Search(ctx context.Context, query string, searchType string) (interface{}, error) {
-	ret := _m.Called(ctx, query, searchType)
+// AlbumTracks provides a mock function with given fields: ctx, id, market
+func (_m *MockSpotifyService) AlbumTracks(ctx context.Context, id string, market string) (*spotify.Tracklist, error) {
+	ret := _m.Called(ctx, id, market)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Search")
+		panic("no return value specified for AlbumTracks")
 	}
 
-	var r0 interface{}
+	var r0 *spotify.Tracklist
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (interface{}, error)); ok {
-		return rf(ctx, query, searchType)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*spotify.Tracklist, error)); ok {
+		return rf(ctx, id, market)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) interface{}); ok {
-		r0 = rf(ctx, query, searchType)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *spotify.Tracklist); ok {
+		r0 = rf(ctx, id, market)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
+			r0 = ret.Get(0).(*spotify.Tracklist)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, query, searchType)
+		r1 = rf(ctx, id, market)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,32 +55,1061 @@ func (_m *MockSpotifyService) Search(ctx context.Context, query string, searchTy
 	return r0, r1
 }
 
-// MockSpotifyService_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
-type MockSpotifyService_Search_Call struct {
+// MockSpotifyService_AlbumTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlbumTracks'
+type MockSpotifyService_AlbumTracks_Call struct {
 	*mock.Call
 }
 
-// Search is a helper method to define mock.On call
+// AlbumTracks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - query string
-//   - searchType string
-func (_e *MockSpotifyService_Expecter) Search(ctx interface{}, query interface{}, searchType interface{}) *MockSpotifyService_Search_Call {
-	return &MockSpotifyService_Search_Call{Call: _e.mock.On("Search", ctx, query, searchType)}
+//   - id string
+//   - market string
+func (_e *MockSpotifyService_Expecter) AlbumTracks(ctx interface{}, id interface{}, market interface{}) *MockSpotifyService_AlbumTracks_Call {
+	return &MockSpotifyService_AlbumTracks_Call{Call: _e.mock.On("AlbumTracks", ctx, id, market)}
+}
+
+func (_c *MockSpotifyService_AlbumTracks_Call) Run(run func(ctx context.Context, id string, market string)) *MockSpotifyService_AlbumTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_AlbumTracks_Call) Return(_a0 *spotify.Tracklist, _a1 error) *MockSpotifyService_AlbumTracks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_AlbumTracks_Call) RunAndReturn(run func(context.Context, string, string) (*spotify.Tracklist, error)) *MockSpotifyService_AlbumTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArtistAlbums provides a mock function with given fields: ctx, id, includeGroups, opts
+func (_m *MockSpotifyService) ArtistAlbums(ctx context.Context, id string, includeGroups []string, opts spotify.SearchOptions) (*spotify.SearchPage, error) {
+	ret := _m.Called(ctx, id, includeGroups, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArtistAlbums")
+	}
+
+	var r0 *spotify.SearchPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SearchOptions) (*spotify.SearchPage, error)); ok {
+		return rf(ctx, id, includeGroups, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SearchOptions) *spotify.SearchPage); ok {
+		r0 = rf(ctx, id, includeGroups, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*spotify.SearchPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, spotify.SearchOptions) error); ok {
+		r1 = rf(ctx, id, includeGroups, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_ArtistAlbums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArtistAlbums'
+type MockSpotifyService_ArtistAlbums_Call struct {
+	*mock.Call
+}
+
+// ArtistAlbums is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - includeGroups []string
+//   - opts spotify.SearchOptions
+func (_e *MockSpotifyService_Expecter) ArtistAlbums(ctx interface{}, id interface{}, includeGroups interface{}, opts interface{}) *MockSpotifyService_ArtistAlbums_Call {
+	return &MockSpotifyService_ArtistAlbums_Call{Call: _e.mock.On("ArtistAlbums", ctx, id, includeGroups, opts)}
+}
+
+func (_c *MockSpotifyService_ArtistAlbums_Call) Run(run func(ctx context.Context, id string, includeGroups []string, opts spotify.SearchOptions)) *MockSpotifyService_ArtistAlbums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(spotify.SearchOptions))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_ArtistAlbums_Call) Return(_a0 *spotify.SearchPage, _a1 error) *MockSpotifyService_ArtistAlbums_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_ArtistAlbums_Call) RunAndReturn(run func(context.Context, string, []string, spotify.SearchOptions) (*spotify.SearchPage, error)) *MockSpotifyService_ArtistAlbums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArtistProfile provides a mock function with given fields: ctx, id, market
+func (_m *MockSpotifyService) ArtistProfile(ctx context.Context, id string, market string) (*spotify.ArtistProfile, error) {
+	ret := _m.Called(ctx, id, market)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArtistProfile")
+	}
+
+	var r0 *spotify.ArtistProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*spotify.ArtistProfile, error)); ok {
+		return rf(ctx, id, market)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *spotify.ArtistProfile); ok {
+		r0 = rf(ctx, id, market)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*spotify.ArtistProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, market)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_ArtistProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArtistProfile'
+type MockSpotifyService_ArtistProfile_Call struct {
+	*mock.Call
+}
+
+// ArtistProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - market string
+func (_e *MockSpotifyService_Expecter) ArtistProfile(ctx interface{}, id interface{}, market interface{}) *MockSpotifyService_ArtistProfile_Call {
+	return &MockSpotifyService_ArtistProfile_Call{Call: _e.mock.On("ArtistProfile", ctx, id, market)}
+}
+
+func (_c *MockSpotifyService_ArtistProfile_Call) Run(run func(ctx context.Context, id string, market string)) *MockSpotifyService_ArtistProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_ArtistProfile_Call) Return(_a0 *spotify.ArtistProfile, _a1 error) *MockSpotifyService_ArtistProfile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_ArtistProfile_Call) RunAndReturn(run func(context.Context, string, string) (*spotify.ArtistProfile, error)) *MockSpotifyService_ArtistProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArtistTopTracks provides a mock function with given fields: ctx, id, market
+func (_m *MockSpotifyService) ArtistTopTracks(ctx context.Context, id string, market string) ([]domain.Item, error) {
+	ret := _m.Called(ctx, id, market)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArtistTopTracks")
+	}
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.Item, error)); ok {
+		return rf(ctx, id, market)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domain.Item); ok {
+		r0 = rf(ctx, id, market)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, market)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_ArtistTopTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArtistTopTracks'
+type MockSpotifyService_ArtistTopTracks_Call struct {
+	*mock.Call
 }
 
-func (_c *MockSpotifyService_Search_Call) Run(run func(ctx context.Context, query string, searchType string)) *MockSpotifyService_Search_Call {
+// ArtistTopTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - market string
+func (_e *MockSpotifyService_Expecter) ArtistTopTracks(ctx interface{}, id interface{}, market interface{}) *MockSpotifyService_ArtistTopTracks_Call {
+	return &MockSpotifyService_ArtistTopTracks_Call{Call: _e.mock.On("ArtistTopTracks", ctx, id, market)}
+}
+
+func (_c *MockSpotifyService_ArtistTopTracks_Call) Run(run func(ctx context.Context, id string, market string)) *MockSpotifyService_ArtistTopTracks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockSpotifyService_Search_Call) Return(_a0 interface{}, _a1 error) *MockSpotifyService_Search_Call {
+func (_c *MockSpotifyService_ArtistTopTracks_Call) Return(_a0 []domain.Item, _a1 error) *MockSpotifyService_ArtistTopTracks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_ArtistTopTracks_Call) RunAndReturn(run func(context.Context, string, string) ([]domain.Item, error)) *MockSpotifyService_ArtistTopTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BatchSearch provides a mock function with given fields: ctx, requests
+func (_m *MockSpotifyService) BatchSearch(ctx context.Context, requests []spotify.BatchRequest) ([]spotify.BatchResult, error) {
+	ret := _m.Called(ctx, requests)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchSearch")
+	}
+
+	var r0 []spotify.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []spotify.BatchRequest) ([]spotify.BatchResult, error)); ok {
+		return rf(ctx, requests)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []spotify.BatchRequest) []spotify.BatchResult); ok {
+		r0 = rf(ctx, requests)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]spotify.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []spotify.BatchRequest) error); ok {
+		r1 = rf(ctx, requests)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_BatchSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchSearch'
+type MockSpotifyService_BatchSearch_Call struct {
+	*mock.Call
+}
+
+// BatchSearch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - requests []spotify.BatchRequest
+func (_e *MockSpotifyService_Expecter) BatchSearch(ctx interface{}, requests interface{}) *MockSpotifyService_BatchSearch_Call {
+	return &MockSpotifyService_BatchSearch_Call{Call: _e.mock.On("BatchSearch", ctx, requests)}
+}
+
+func (_c *MockSpotifyService_BatchSearch_Call) Run(run func(ctx context.Context, requests []spotify.BatchRequest)) *MockSpotifyService_BatchSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]spotify.BatchRequest))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_BatchSearch_Call) Return(_a0 []spotify.BatchResult, _a1 error) *MockSpotifyService_BatchSearch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_BatchSearch_Call) RunAndReturn(run func(context.Context, []spotify.BatchRequest) ([]spotify.BatchResult, error)) *MockSpotifyService_BatchSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BestMatches provides a mock function with given fields: ctx, query, searchTypes, opts, matchOpts
+func (_m *MockSpotifyService) BestMatches(ctx context.Context, query string, searchTypes []string, opts spotify.SearchOptions, matchOpts spotify.MatchOptions) (map[string]*spotify.Match, error) {
+	ret := _m.Called(ctx, query, searchTypes, opts, matchOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BestMatches")
+	}
+
+	var r0 map[string]*spotify.Match
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SearchOptions, spotify.MatchOptions) (map[string]*spotify.Match, error)); ok {
+		return rf(ctx, query, searchTypes, opts, matchOpts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SearchOptions, spotify.MatchOptions) map[string]*spotify.Match); ok {
+		r0 = rf(ctx, query, searchTypes, opts, matchOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*spotify.Match)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, spotify.SearchOptions, spotify.MatchOptions) error); ok {
+		r1 = rf(ctx, query, searchTypes, opts, matchOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_BestMatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BestMatches'
+type MockSpotifyService_BestMatches_Call struct {
+	*mock.Call
+}
+
+// BestMatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - searchTypes []string
+//   - opts spotify.SearchOptions
+//   - matchOpts spotify.MatchOptions
+func (_e *MockSpotifyService_Expecter) BestMatches(ctx interface{}, query interface{}, searchTypes interface{}, opts interface{}, matchOpts interface{}) *MockSpotifyService_BestMatches_Call {
+	return &MockSpotifyService_BestMatches_Call{Call: _e.mock.On("BestMatches", ctx, query, searchTypes, opts, matchOpts)}
+}
+
+func (_c *MockSpotifyService_BestMatches_Call) Run(run func(ctx context.Context, query string, searchTypes []string, opts spotify.SearchOptions, matchOpts spotify.MatchOptions)) *MockSpotifyService_BestMatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(spotify.SearchOptions), args[4].(spotify.MatchOptions))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_BestMatches_Call) Return(_a0 map[string]*spotify.Match, _a1 error) *MockSpotifyService_BestMatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_BestMatches_Call) RunAndReturn(run func(context.Context, string, []string, spotify.SearchOptions, spotify.MatchOptions) (map[string]*spotify.Match, error)) *MockSpotifyService_BestMatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BumpGeneration provides a mock function with given fields: ctx
+func (_m *MockSpotifyService) BumpGeneration(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BumpGeneration")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_BumpGeneration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BumpGeneration'
+type MockSpotifyService_BumpGeneration_Call struct {
+	*mock.Call
+}
+
+// BumpGeneration is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSpotifyService_Expecter) BumpGeneration(ctx interface{}) *MockSpotifyService_BumpGeneration_Call {
+	return &MockSpotifyService_BumpGeneration_Call{Call: _e.mock.On("BumpGeneration", ctx)}
+}
+
+func (_c *MockSpotifyService_BumpGeneration_Call) Run(run func(ctx context.Context)) *MockSpotifyService_BumpGeneration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_BumpGeneration_Call) Return(_a0 int64, _a1 error) *MockSpotifyService_BumpGeneration_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_BumpGeneration_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockSpotifyService_BumpGeneration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, itemType, id, market
+func (_m *MockSpotifyService) GetByID(ctx context.Context, itemType string, id string, market string) (domain.Item, error) {
+	ret := _m.Called(ctx, itemType, id, market)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (domain.Item, error)); ok {
+		return rf(ctx, itemType, id, market)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) domain.Item); ok {
+		r0 = rf(ctx, itemType, id, market)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, itemType, id, market)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockSpotifyService_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - itemType string
+//   - id string
+//   - market string
+func (_e *MockSpotifyService_Expecter) GetByID(ctx interface{}, itemType interface{}, id interface{}, market interface{}) *MockSpotifyService_GetByID_Call {
+	return &MockSpotifyService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, itemType, id, market)}
+}
+
+func (_c *MockSpotifyService_GetByID_Call) Run(run func(ctx context.Context, itemType string, id string, market string)) *MockSpotifyService_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_GetByID_Call) Return(_a0 domain.Item, _a1 error) *MockSpotifyService_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_GetByID_Call) RunAndReturn(run func(context.Context, string, string, string) (domain.Item, error)) *MockSpotifyService_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDs provides a mock function with given fields: ctx, itemType, ids, market
+func (_m *MockSpotifyService) GetByIDs(ctx context.Context, itemType string, ids []string, market string) ([]domain.Item, error) {
+	ret := _m.Called(ctx, itemType, ids, market)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string) ([]domain.Item, error)); ok {
+		return rf(ctx, itemType, ids, market)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string) []domain.Item); ok {
+		r0 = rf(ctx, itemType, ids, market)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, string) error); ok {
+		r1 = rf(ctx, itemType, ids, market)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockSpotifyService_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - itemType string
+//   - ids []string
+//   - market string
+func (_e *MockSpotifyService_Expecter) GetByIDs(ctx interface{}, itemType interface{}, ids interface{}, market interface{}) *MockSpotifyService_GetByIDs_Call {
+	return &MockSpotifyService_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, itemType, ids, market)}
+}
+
+func (_c *MockSpotifyService_GetByIDs_Call) Run(run func(ctx context.Context, itemType string, ids []string, market string)) *MockSpotifyService_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_GetByIDs_Call) Return(_a0 []domain.Item, _a1 error) *MockSpotifyService_GetByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_GetByIDs_Call) RunAndReturn(run func(context.Context, string, []string, string) ([]domain.Item, error)) *MockSpotifyService_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lookup provides a mock function with given fields: ctx, lookupType, artist, name, opts, matchOpts
+func (_m *MockSpotifyService) Lookup(ctx context.Context, lookupType string, artist string, name string, opts spotify.SearchOptions, matchOpts spotify.MatchOptions) (*spotify.Match, error) {
+	ret := _m.Called(ctx, lookupType, artist, name, opts, matchOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lookup")
+	}
+
+	var r0 *spotify.Match
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, spotify.SearchOptions, spotify.MatchOptions) (*spotify.Match, error)); ok {
+		return rf(ctx, lookupType, artist, name, opts, matchOpts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, spotify.SearchOptions, spotify.MatchOptions) *spotify.Match); ok {
+		r0 = rf(ctx, lookupType, artist, name, opts, matchOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*spotify.Match)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, spotify.SearchOptions, spotify.MatchOptions) error); ok {
+		r1 = rf(ctx, lookupType, artist, name, opts, matchOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_Lookup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lookup'
+type MockSpotifyService_Lookup_Call struct {
+	*mock.Call
+}
+
+// Lookup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lookupType string
+//   - artist string
+//   - name string
+//   - opts spotify.SearchOptions
+//   - matchOpts spotify.MatchOptions
+func (_e *MockSpotifyService_Expecter) Lookup(ctx interface{}, lookupType interface{}, artist interface{}, name interface{}, opts interface{}, matchOpts interface{}) *MockSpotifyService_Lookup_Call {
+	return &MockSpotifyService_Lookup_Call{Call: _e.mock.On("Lookup", ctx, lookupType, artist, name, opts, matchOpts)}
+}
+
+func (_c *MockSpotifyService_Lookup_Call) Run(run func(ctx context.Context, lookupType string, artist string, name string, opts spotify.SearchOptions, matchOpts spotify.MatchOptions)) *MockSpotifyService_Lookup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(spotify.SearchOptions), args[5].(spotify.MatchOptions))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_Lookup_Call) Return(_a0 *spotify.Match, _a1 error) *MockSpotifyService_Lookup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_Lookup_Call) RunAndReturn(run func(context.Context, string, string, string, spotify.SearchOptions, spotify.MatchOptions) (*spotify.Match, error)) *MockSpotifyService_Lookup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LookupISRC provides a mock function with given fields: ctx, isrc, market, prefer
+func (_m *MockSpotifyService) LookupISRC(ctx context.Context, isrc string, market string, prefer string) ([]domain.Item, error) {
+	ret := _m.Called(ctx, isrc, market, prefer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupISRC")
+	}
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]domain.Item, error)); ok {
+		return rf(ctx, isrc, market, prefer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []domain.Item); ok {
+		r0 = rf(ctx, isrc, market, prefer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, isrc, market, prefer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_LookupISRC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupISRC'
+type MockSpotifyService_LookupISRC_Call struct {
+	*mock.Call
+}
+
+// LookupISRC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - isrc string
+//   - market string
+//   - prefer string
+func (_e *MockSpotifyService_Expecter) LookupISRC(ctx interface{}, isrc interface{}, market interface{}, prefer interface{}) *MockSpotifyService_LookupISRC_Call {
+	return &MockSpotifyService_LookupISRC_Call{Call: _e.mock.On("LookupISRC", ctx, isrc, market, prefer)}
+}
+
+func (_c *MockSpotifyService_LookupISRC_Call) Run(run func(ctx context.Context, isrc string, market string, prefer string)) *MockSpotifyService_LookupISRC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_LookupISRC_Call) Return(_a0 []domain.Item, _a1 error) *MockSpotifyService_LookupISRC_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_LookupISRC_Call) RunAndReturn(run func(context.Context, string, string, string) ([]domain.Item, error)) *MockSpotifyService_LookupISRC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LookupUPC provides a mock function with given fields: ctx, upc, market, prefer
+func (_m *MockSpotifyService) LookupUPC(ctx context.Context, upc string, market string, prefer string) ([]domain.Item, error) {
+	ret := _m.Called(ctx, upc, market, prefer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupUPC")
+	}
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]domain.Item, error)); ok {
+		return rf(ctx, upc, market, prefer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []domain.Item); ok {
+		r0 = rf(ctx, upc, market, prefer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, upc, market, prefer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_LookupUPC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupUPC'
+type MockSpotifyService_LookupUPC_Call struct {
+	*mock.Call
+}
+
+// LookupUPC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - upc string
+//   - market string
+//   - prefer string
+func (_e *MockSpotifyService_Expecter) LookupUPC(ctx interface{}, upc interface{}, market interface{}, prefer interface{}) *MockSpotifyService_LookupUPC_Call {
+	return &MockSpotifyService_LookupUPC_Call{Call: _e.mock.On("LookupUPC", ctx, upc, market, prefer)}
+}
+
+func (_c *MockSpotifyService_LookupUPC_Call) Run(run func(ctx context.Context, upc string, market string, prefer string)) *MockSpotifyService_LookupUPC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_LookupUPC_Call) Return(_a0 []domain.Item, _a1 error) *MockSpotifyService_LookupUPC_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_LookupUPC_Call) RunAndReturn(run func(context.Context, string, string, string) ([]domain.Item, error)) *MockSpotifyService_LookupUPC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MultiSearch provides a mock function with given fields: ctx, query, searchTypes, opts
+func (_m *MockSpotifyService) MultiSearch(ctx context.Context, query string, searchTypes []string, opts spotify.SearchOptions) (map[string]*spotify.SearchPage, error) {
+	ret := _m.Called(ctx, query, searchTypes, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MultiSearch")
+	}
+
+	var r0 map[string]*spotify.SearchPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SearchOptions) (map[string]*spotify.SearchPage, error)); ok {
+		return rf(ctx, query, searchTypes, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SearchOptions) map[string]*spotify.SearchPage); ok {
+		r0 = rf(ctx, query, searchTypes, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*spotify.SearchPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, spotify.SearchOptions) error); ok {
+		r1 = rf(ctx, query, searchTypes, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_MultiSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MultiSearch'
+type MockSpotifyService_MultiSearch_Call struct {
+	*mock.Call
+}
+
+// MultiSearch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - searchTypes []string
+//   - opts spotify.SearchOptions
+func (_e *MockSpotifyService_Expecter) MultiSearch(ctx interface{}, query interface{}, searchTypes interface{}, opts interface{}) *MockSpotifyService_MultiSearch_Call {
+	return &MockSpotifyService_MultiSearch_Call{Call: _e.mock.On("MultiSearch", ctx, query, searchTypes, opts)}
+}
+
+func (_c *MockSpotifyService_MultiSearch_Call) Run(run func(ctx context.Context, query string, searchTypes []string, opts spotify.SearchOptions)) *MockSpotifyService_MultiSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(spotify.SearchOptions))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_MultiSearch_Call) Return(_a0 map[string]*spotify.SearchPage, _a1 error) *MockSpotifyService_MultiSearch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_MultiSearch_Call) RunAndReturn(run func(context.Context, string, []string, spotify.SearchOptions) (map[string]*spotify.SearchPage, error)) *MockSpotifyService_MultiSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RelatedArtists provides a mock function with given fields: ctx, id
+func (_m *MockSpotifyService) RelatedArtists(ctx context.Context, id string) ([]domain.Item, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RelatedArtists")
+	}
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Item, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Item); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_RelatedArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RelatedArtists'
+type MockSpotifyService_RelatedArtists_Call struct {
+	*mock.Call
+}
+
+// RelatedArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockSpotifyService_Expecter) RelatedArtists(ctx interface{}, id interface{}) *MockSpotifyService_RelatedArtists_Call {
+	return &MockSpotifyService_RelatedArtists_Call{Call: _e.mock.On("RelatedArtists", ctx, id)}
+}
+
+func (_c *MockSpotifyService_RelatedArtists_Call) Run(run func(ctx context.Context, id string)) *MockSpotifyService_RelatedArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_RelatedArtists_Call) Return(_a0 []domain.Item, _a1 error) *MockSpotifyService_RelatedArtists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_RelatedArtists_Call) RunAndReturn(run func(context.Context, string) ([]domain.Item, error)) *MockSpotifyService_RelatedArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resolve provides a mock function with given fields: ctx, link, market
+func (_m *MockSpotifyService) Resolve(ctx context.Context, link string, market string) (domain.Item, error) {
+	ret := _m.Called(ctx, link, market)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.Item, error)); ok {
+		return rf(ctx, link, market)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Item); ok {
+		r0 = rf(ctx, link, market)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, link, market)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockSpotifyService_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link string
+//   - market string
+func (_e *MockSpotifyService_Expecter) Resolve(ctx interface{}, link interface{}, market interface{}) *MockSpotifyService_Resolve_Call {
+	return &MockSpotifyService_Resolve_Call{Call: _e.mock.On("Resolve", ctx, link, market)}
+}
+
+func (_c *MockSpotifyService_Resolve_Call) Run(run func(ctx context.Context, link string, market string)) *MockSpotifyService_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_Resolve_Call) Return(_a0 domain.Item, _a1 error) *MockSpotifyService_Resolve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_Resolve_Call) RunAndReturn(run func(context.Context, string, string) (domain.Item, error)) *MockSpotifyService_Resolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, query, searchType, opts, matchOpts
+func (_m *MockSpotifyService) Search(ctx context.Context, query string, searchType string, opts spotify.SearchOptions, matchOpts spotify.MatchOptions) (*spotify.Match, error) {
+	ret := _m.Called(ctx, query, searchType, opts, matchOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *spotify.Match
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, spotify.SearchOptions, spotify.MatchOptions) (*spotify.Match, error)); ok {
+		return rf(ctx, query, searchType, opts, matchOpts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, spotify.SearchOptions, spotify.MatchOptions) *spotify.Match); ok {
+		r0 = rf(ctx, query, searchType, opts, matchOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*spotify.Match)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, spotify.SearchOptions, spotify.MatchOptions) error); ok {
+		r1 = rf(ctx, query, searchType, opts, matchOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockSpotifyService_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - searchType string
+//   - opts spotify.SearchOptions
+//   - matchOpts spotify.MatchOptions
+func (_e *MockSpotifyService_Expecter) Search(ctx interface{}, query interface{}, searchType interface{}, opts interface{}, matchOpts interface{}) *MockSpotifyService_Search_Call {
+	return &MockSpotifyService_Search_Call{Call: _e.mock.On("Search", ctx, query, searchType, opts, matchOpts)}
+}
+
+func (_c *MockSpotifyService_Search_Call) Run(run func(ctx context.Context, query string, searchType string, opts spotify.SearchOptions, matchOpts spotify.MatchOptions)) *MockSpotifyService_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(spotify.SearchOptions), args[4].(spotify.MatchOptions))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_Search_Call) Return(_a0 *spotify.Match, _a1 error) *MockSpotifyService_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_Search_Call) RunAndReturn(run func(context.Context, string, string, spotify.SearchOptions, spotify.MatchOptions) (*spotify.Match, error)) *MockSpotifyService_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchPage provides a mock function with given fields: ctx, query, searchType, opts
+func (_m *MockSpotifyService) SearchPage(ctx context.Context, query string, searchType string, opts spotify.SearchOptions) (*spotify.SearchPage, error) {
+	ret := _m.Called(ctx, query, searchType, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchPage")
+	}
+
+	var r0 *spotify.SearchPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, spotify.SearchOptions) (*spotify.SearchPage, error)); ok {
+		return rf(ctx, query, searchType, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, spotify.SearchOptions) *spotify.SearchPage); ok {
+		r0 = rf(ctx, query, searchType, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*spotify.SearchPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, spotify.SearchOptions) error); ok {
+		r1 = rf(ctx, query, searchType, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_SearchPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchPage'
+type MockSpotifyService_SearchPage_Call struct {
+	*mock.Call
+}
+
+// SearchPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - searchType string
+//   - opts spotify.SearchOptions
+func (_e *MockSpotifyService_Expecter) SearchPage(ctx interface{}, query interface{}, searchType interface{}, opts interface{}) *MockSpotifyService_SearchPage_Call {
+	return &MockSpotifyService_SearchPage_Call{Call: _e.mock.On("SearchPage", ctx, query, searchType, opts)}
+}
+
+func (_c *MockSpotifyService_SearchPage_Call) Run(run func(ctx context.Context, query string, searchType string, opts spotify.SearchOptions)) *MockSpotifyService_SearchPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(spotify.SearchOptions))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_SearchPage_Call) Return(_a0 *spotify.SearchPage, _a1 error) *MockSpotifyService_SearchPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpotifyService_SearchPage_Call) RunAndReturn(run func(context.Context, string, string, spotify.SearchOptions) (*spotify.SearchPage, error)) *MockSpotifyService_SearchPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Suggest provides a mock function with given fields: ctx, query, searchTypes, opts
+func (_m *MockSpotifyService) Suggest(ctx context.Context, query string, searchTypes []string, opts spotify.SuggestOptions) ([]spotify.Suggestion, error) {
+	ret := _m.Called(ctx, query, searchTypes, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Suggest")
+	}
+
+	var r0 []spotify.Suggestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SuggestOptions) ([]spotify.Suggestion, error)); ok {
+		return rf(ctx, query, searchTypes, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, spotify.SuggestOptions) []spotify.Suggestion); ok {
+		r0 = rf(ctx, query, searchTypes, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]spotify.Suggestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, spotify.SuggestOptions) error); ok {
+		r1 = rf(ctx, query, searchTypes, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpotifyService_Suggest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Suggest'
+type MockSpotifyService_Suggest_Call struct {
+	*mock.Call
+}
+
+// Suggest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - searchTypes []string
+//   - opts spotify.SuggestOptions
+func (_e *MockSpotifyService_Expecter) Suggest(ctx interface{}, query interface{}, searchTypes interface{}, opts interface{}) *MockSpotifyService_Suggest_Call {
+	return &MockSpotifyService_Suggest_Call{Call: _e.mock.On("Suggest", ctx, query, searchTypes, opts)}
+}
+
+func (_c *MockSpotifyService_Suggest_Call) Run(run func(ctx context.Context, query string, searchTypes []string, opts spotify.SuggestOptions)) *MockSpotifyService_Suggest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(spotify.SuggestOptions))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyService_Suggest_Call) Return(_a0 []spotify.Suggestion, _a1 error) *MockSpotifyService_Suggest_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSpotifyService_Search_Call) RunAndReturn(run func(context.Context, string, string) (interface{}, error)) *MockSpotifyService_Search_Call {
+func (_c *MockSpotifyService_Suggest_Call) RunAndReturn(run func(context.Context, string, []string, spotify.SuggestOptions) ([]spotify.Suggestion, error)) *MockSpotifyService_Suggest_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,36 @@
+package spotify
+
+import (
+	"net/http"
+
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/gin-gonic/gin"
+)
+
+// Resolve responds with the artist, album or track of a Spotify link or URI
+// given in the url parameter.
+func (h *SpotifyHandler) Resolve(c *gin.Context) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.Resolve")
+	defer span.End()
+
+	link := c.Query("url")
+	if link == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "url is required"})
+		return
+	}
+
+	// The type is only known once the link is resolved
+	fields, validFields, err := parseFields(c.Query("fields"), appspotify.IDTypes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_fields": validFields})
+		return
+	}
+
+	item, err := h.spotifySearchService.Resolve(ctx, link, regionalOptions(c).Market)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	respond(c, item, fields)
+}
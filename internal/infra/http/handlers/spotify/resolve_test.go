@@ -0,0 +1,79 @@
+package spotify_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifyHandler_Resolve(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		rawQuery       string
+		link           string
+		serviceItem    domain.Item
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "link",
+			rawQuery:       "url=" + url.QueryEscape("https://open.spotify.com/track/2pWnuwM6fPpsk9kTPxkDpM?si=abc") + "&fields=id,type",
+			link:           "https://open.spotify.com/track/2pWnuwM6fPpsk9kTPxkDpM?si=abc",
+			serviceItem:    &domain.Track{Object: domain.Object{ID: "2pWnuwM6fPpsk9kTPxkDpM", Type: "track", Name: "FANCY"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id": "2pWnuwM6fPpsk9kTPxkDpM", "type": "track"}`,
+		},
+		{
+			name:           "invalid link",
+			rawQuery:       "url=spotify:playlist:37i9dQZF1DXcBWIGoYBM5M",
+			link:           "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M",
+			serviceErr:     fmt.Errorf("%w: playlist links are not supported", appspotify.ErrInvalidLink),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid link: playlist links are not supported"}`,
+		},
+		{
+			name:           "missing url",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "url is required"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/resolve?"+tt.rawQuery, nil)
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+			if tt.link != "" {
+				mockService.On("Resolve", mock.Anything, tt.link, "").
+					Return(tt.serviceItem, tt.serviceErr).
+					Once()
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.Resolve(ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
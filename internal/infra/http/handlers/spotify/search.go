@@ -2,49 +2,203 @@ package spotify
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	searchModeSingle = "single"
+	searchModeList   = "list"
+)
+
+var errInvalidMode = errors.New("mode must be single or list")
+
 func (h *SpotifyHandler) Search(c *gin.Context) {
 	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.Search")
 	defer span.End()
 
-	qType := c.Param("type")
-	if qType == "" {
+	// Several types can be searched at once, either as a comma-separated
+	// path segment (/search/artist,track/...) or with ?types=artist,track.
+	qTypes := parseSearchTypes(c.Param("type"), c.Query("types"))
+	if len(qTypes) == 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "type is required"})
 		return
 	}
 
+	// The query can be left empty when searching with filters only, e.g.
+	// /search/track/?artist=...&track=...
 	query := strings.TrimPrefix(c.Param("query"), "/")
-	if query == "" {
+	filters := searchFilters(c)
+	if query == "" && filters.IsZero() {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "query is required"})
 		return
 	}
 
-	result, err := h.spotifySearchService.Search(ctx, query, qType)
+	fields, validFields, err := parseFields(c.Query("fields"), qTypes)
 	if err != nil {
-		status := http.StatusInternalServerError
-		message := "internal server error"
-
-		switch {
-		case errors.Is(err, appspotify.ErrInvalidQueryType):
-			status = http.StatusBadRequest
-			message = "invalid search type"
-		case errors.Is(err, appspotify.ErrNoResultsFound):
-			status = http.StatusNotFound
-			message = "no results found"
-		case errors.Is(err, appspotify.ErrSpotifyClient):
-			status = http.StatusBadGateway
-			message = "spotify client error"
-		}
-
-		c.JSON(status, gin.H{"error": message})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_fields": validFields})
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	limit, limitSet := c.GetQuery("limit")
+	offset, offsetSet := c.GetQuery("offset")
+
+	// Without any paging parameter we keep returning the first hit only,
+	// as existing clients expect.
+	mode := c.Query("mode")
+	if mode == "" {
+		mode = searchModeSingle
+		if limitSet || offsetSet {
+			mode = searchModeList
+		}
+	}
+
+	switch mode {
+	case searchModeSingle:
+		matchOpts, err := matchOptions(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		opts := regionalOptions(c)
+		opts.Filters = filters
+
+		if len(qTypes) > 1 {
+			h.multiSearchBest(c, query, qTypes, opts, matchOpts, perType(matchFields(fields), qTypes))
+			return
+		}
+
+		match, err := h.spotifySearchService.Search(ctx, query, qTypes[0], opts, matchOpts)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		respond(c, match, matchFields(fields))
+	case searchModeList:
+		opts := regionalOptions(c)
+		opts.Filters = filters
+
+		if limitSet {
+			opts.Limit, err = strconv.Atoi(limit)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be an integer"})
+				return
+			}
+		}
+		if offsetSet {
+			opts.Offset, err = strconv.Atoi(offset)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be an integer"})
+				return
+			}
+		}
+
+		if len(qTypes) > 1 {
+			pages, err := h.spotifySearchService.MultiSearch(ctx, query, qTypes, opts)
+			if err != nil {
+				writeError(c, err)
+				return
+			}
+
+			respond(c, pages, perType(pageFields(fields), qTypes))
+			return
+		}
+
+		page, err := h.spotifySearchService.SearchPage(ctx, query, qTypes[0], opts)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		respond(c, page, pageFields(fields))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidMode.Error()})
+	}
+}
+
+// multiSearchBest responds with the best match of each type, or null for
+// types without a good enough match.
+func (h *SpotifyHandler) multiSearchBest(
+	c *gin.Context,
+	query string,
+	qTypes []string,
+	opts appspotify.SearchOptions,
+	matchOpts appspotify.MatchOptions,
+	fields fieldTree,
+) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.multiSearchBest")
+	defer span.End()
+
+	matches, err := h.spotifySearchService.BestMatches(ctx, query, qTypes, opts, matchOpts)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	found := false
+	for _, match := range matches {
+		if match != nil {
+			found = true
+		}
+	}
+
+	if !found {
+		writeError(c, appspotify.ErrNoResultsFound)
+		return
+	}
+
+	respond(c, matches, fields)
+}
+
+func matchOptions(c *gin.Context) (appspotify.MatchOptions, error) {
+	var matchOpts appspotify.MatchOptions
+	var err error
+
+	if value := c.Query("min_confidence"); value != "" {
+		matchOpts.MinConfidence, err = strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(matchOpts.MinConfidence) {
+			return matchOpts, errors.New("min_confidence must be a number")
+		}
+	}
+	if value := c.Query("explain"); value != "" {
+		matchOpts.Explain, err = strconv.ParseBool(value)
+		if err != nil {
+			return matchOpts, errors.New("explain must be a boolean")
+		}
+	}
+
+	return matchOpts, nil
+}
+
+func searchFilters(c *gin.Context) appspotify.SearchFilters {
+	return appspotify.SearchFilters{
+		Artist: c.Query("artist"),
+		Album:  c.Query("album"),
+		Track:  c.Query("track"),
+		Year:   c.Query("year"),
+		Genre:  c.Query("genre"),
+		ISRC:   c.Query("isrc"),
+		UPC:    c.Query("upc"),
+		Tag:    c.Query("tag"),
+	}
+}
+
+func parseSearchTypes(values ...string) []string {
+	var qTypes []string
+	for _, value := range values {
+		for _, qType := range strings.Split(value, ",") {
+			qType = strings.TrimSpace(qType)
+			if qType != "" {
+				qTypes = append(qTypes, qType)
+			}
+		}
+	}
+	return qTypes
 }
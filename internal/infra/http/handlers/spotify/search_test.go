@@ -2,10 +2,13 @@ package spotify_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
 	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
 	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
 	"github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify/mocks"
@@ -24,7 +27,7 @@ func TestSpotifyHandler_SearchStatuses(t *testing.T) {
 		pathType       string
 		rawQuery       string
 		expectedQuery  string
-		serviceResult  any
+		serviceResult  *appspotify.Match
 		serviceErr     error
 		expectedStatus int
 		expectedBody   map[string]string
@@ -65,12 +68,20 @@ func TestSpotifyHandler_SearchStatuses(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   map[string]string{"error": "internal server error"},
 		},
+		{
+			name:           "podcast search",
+			pathType:       "show",
+			rawQuery:       "kpop",
+			expectedQuery:  "kpop",
+			serviceResult:  &appspotify.Match{Item: &domain.Show{Object: domain.Object{Type: "show", Name: "K-Pop Daebak"}}, Confidence: 0.9},
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name:           "success with slash",
 			pathType:       "artist",
 			rawQuery:       "/AC/DC",
 			expectedQuery:  "AC/DC",
-			serviceResult:  map[string]string{"name": "AC/DC"},
+			serviceResult:  &appspotify.Match{Item: &domain.Artist{Object: domain.Object{Type: "artist", Name: "AC/DC"}}, Confidence: 1},
 			expectedStatus: http.StatusOK,
 		},
 	}
@@ -93,11 +104,11 @@ func TestSpotifyHandler_SearchStatuses(t *testing.T) {
 			})
 
 			if tt.serviceErr != nil {
-				mockService.On("Search", mock.Anything, tt.expectedQuery, tt.pathType).
+				mockService.On("Search", mock.Anything, tt.expectedQuery, tt.pathType, appspotify.SearchOptions{}, appspotify.MatchOptions{}).
 					Return(nil, tt.serviceErr).
 					Once()
 			} else {
-				mockService.On("Search", mock.Anything, tt.expectedQuery, tt.pathType).
+				mockService.On("Search", mock.Anything, tt.expectedQuery, tt.pathType, appspotify.SearchOptions{}, appspotify.MatchOptions{}).
 					Return(tt.serviceResult, nil).
 					Once()
 			}
@@ -108,9 +119,15 @@ func TestSpotifyHandler_SearchStatuses(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, recorder.Code)
 
 			if tt.expectedStatus == http.StatusOK {
-				var payload map[string]string
-				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &payload))
-				assert.Equal(t, tt.serviceResult, payload)
+				payload, err := domain.UnmarshalItem(recorder.Body.Bytes())
+				require.NoError(t, err)
+				assert.Equal(t, tt.serviceResult.Item, payload)
+
+				var confidence struct {
+					Confidence float64 `json:"confidence"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &confidence))
+				assert.Equal(t, tt.serviceResult.Confidence, confidence.Confidence)
 				return
 			}
 
@@ -120,3 +137,505 @@ func TestSpotifyHandler_SearchStatuses(t *testing.T) {
 		})
 	}
 }
+
+func TestSpotifyHandler_SearchList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	next := 20
+
+	tests := []struct {
+		name           string
+		path           string
+		rawQuery       string
+		expectedOpts   *appspotify.SearchOptions
+		servicePage    *appspotify.SearchPage
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "limit and offset",
+			rawQuery:       "limit=10&offset=10",
+			expectedOpts:   &appspotify.SearchOptions{Limit: 10, Offset: 10},
+			servicePage:    &appspotify.SearchPage{Items: []domain.Item{}, Total: 42, Limit: 10, Offset: 10, Next: &next},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "list mode without paging",
+			rawQuery:       "mode=list",
+			expectedOpts:   &appspotify.SearchOptions{},
+			servicePage:    &appspotify.SearchPage{Items: []domain.Item{}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid pagination",
+			rawQuery:       "limit=100",
+			expectedOpts:   &appspotify.SearchOptions{Limit: 100},
+			serviceErr:     appspotify.ErrInvalidPagination,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "non numeric limit",
+			rawQuery:       "limit=ten",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "filters without query",
+			path:     "/search/track/",
+			rawQuery: "limit=10&artist=Daft+Punk&track=Get+Lucky",
+			expectedOpts: &appspotify.SearchOptions{Limit: 10, Filters: appspotify.SearchFilters{
+				Artist: "Daft Punk",
+				Track:  "Get Lucky",
+			}},
+			servicePage:    &appspotify.SearchPage{Items: []domain.Item{}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing query and filters",
+			path:           "/search/track/",
+			rawQuery:       "limit=10",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid filter",
+			rawQuery:       "limit=10&year=90s",
+			expectedOpts:   &appspotify.SearchOptions{Limit: 10, Filters: appspotify.SearchFilters{Year: "90s"}},
+			serviceErr:     appspotify.ErrInvalidFilter,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown mode",
+			rawQuery:       "mode=all",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			path := tt.path
+			if path == "" {
+				path = "/search/artist/twice"
+			}
+			pathType, query, _ := strings.Cut(strings.TrimPrefix(path, "/search/"), "/")
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, path+"?"+tt.rawQuery, nil)
+			ctx.Params = gin.Params{
+				{Key: "type", Value: pathType},
+				{Key: "query", Value: "/" + query},
+			}
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+
+			if tt.expectedOpts != nil {
+				mockService.On("SearchPage", mock.Anything, query, pathType, *tt.expectedOpts).
+					Return(tt.servicePage, tt.serviceErr).
+					Once()
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.Search(ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var payload appspotify.SearchPage
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &payload))
+				assert.Equal(t, tt.servicePage.Total, payload.Total)
+				assert.Equal(t, tt.servicePage.Next, payload.Next)
+			}
+		})
+	}
+}
+
+func TestSpotifyHandler_SearchMultipleTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		pathType       string
+		rawQuery       string
+		expectedTypes  []string
+		expectedOpts   appspotify.SearchOptions
+		serviceMatches map[string]*appspotify.Match
+		servicePages   map[string]*appspotify.SearchPage
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:          "comma separated path",
+			pathType:      "artist,track",
+			expectedTypes: []string{"artist", "track"},
+			serviceMatches: map[string]*appspotify.Match{
+				"artist": {Item: &domain.Artist{Object: domain.Object{Type: "artist", Name: "TWICE"}}, Confidence: 0.95},
+				"track":  nil,
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"artist":{"id":"","type":"artist","name":"TWICE","uri":"","external_urls":null,"genres":null,"images":null,"popularity":0,"followers":{"total":0},"confidence":0.95},"track":null}`,
+		},
+		{
+			name:          "types parameter",
+			pathType:      "artist",
+			rawQuery:      "types=album&limit=5",
+			expectedTypes: []string{"artist", "album"},
+			expectedOpts:  appspotify.SearchOptions{Limit: 5},
+			servicePages: map[string]*appspotify.SearchPage{
+				"artist": {Items: []domain.Item{}},
+				"album":  {Items: []domain.Item{}},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"album":{"items":[],"total":0,"limit":0,"offset":0,"next":null,"previous":null},"artist":{"items":[],"total":0,"limit":0,"offset":0,"next":null,"previous":null}}`,
+		},
+		{
+			name:          "no results for any type",
+			pathType:      "artist,track",
+			expectedTypes: []string{"artist", "track"},
+			serviceMatches: map[string]*appspotify.Match{
+				"artist": nil,
+				"track":  nil,
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"no results found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/search/"+tt.pathType+"/twice?"+tt.rawQuery, nil)
+			ctx.Params = gin.Params{
+				{Key: "type", Value: tt.pathType},
+				{Key: "query", Value: "/twice"},
+			}
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+
+			if tt.servicePages != nil {
+				mockService.On("MultiSearch", mock.Anything, "twice", tt.expectedTypes, tt.expectedOpts).
+					Return(tt.servicePages, nil).
+					Once()
+			} else {
+				mockService.On("BestMatches", mock.Anything, "twice", tt.expectedTypes, tt.expectedOpts, appspotify.MatchOptions{}).
+					Return(tt.serviceMatches, nil).
+					Once()
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.Search(ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestSpotifyHandler_SearchMatchOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	twice := &domain.Artist{Object: domain.Object{Type: "artist", Name: "TWICE"}}
+	tribute := &domain.Artist{Object: domain.Object{Type: "artist", Name: "TWICE Tribute Band"}}
+
+	tests := []struct {
+		name              string
+		rawQuery          string
+		expectedMatchOpts *appspotify.MatchOptions
+		serviceMatch      *appspotify.Match
+		serviceErr        error
+		expectedStatus    int
+		expectedBody      string
+	}{
+		{
+			name:              "min confidence",
+			rawQuery:          "min_confidence=0.8",
+			expectedMatchOpts: &appspotify.MatchOptions{MinConfidence: 0.8},
+			serviceErr:        appspotify.ErrNoResultsFound,
+			expectedStatus:    http.StatusNotFound,
+			expectedBody:      `{"error": "no results found"}`,
+		},
+		{
+			name:              "min confidence out of range",
+			rawQuery:          "min_confidence=2",
+			expectedMatchOpts: &appspotify.MatchOptions{MinConfidence: 2},
+			serviceErr:        fmt.Errorf("%w: must be between 0 and 1", appspotify.ErrInvalidMinConfidence),
+			expectedStatus:    http.StatusBadRequest,
+			expectedBody:      `{"error": "invalid min_confidence: must be between 0 and 1"}`,
+		},
+		{
+			name:           "min confidence not a number",
+			rawQuery:       "min_confidence=high",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "min_confidence must be a number"}`,
+		},
+		{
+			name:           "min confidence NaN",
+			rawQuery:       "min_confidence=NaN",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "min_confidence must be a number"}`,
+		},
+		{
+			name:           "explain not a boolean",
+			rawQuery:       "explain=please",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "explain must be a boolean"}`,
+		},
+		{
+			name:              "explain",
+			rawQuery:          "explain=true&fields=name",
+			expectedMatchOpts: &appspotify.MatchOptions{Explain: true},
+			serviceMatch: &appspotify.Match{
+				Item:       twice,
+				Confidence: 0.95,
+				Candidates: []appspotify.Candidate{
+					{Item: twice, Score: 0.95, Similarity: 1, Exact: true},
+					{Item: tribute, Score: 0.1, Similarity: 0.278, Noise: true},
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"name": "TWICE",
+				"confidence": 0.95,
+				"candidates": [
+					{"item": {"id": "", "type": "artist", "name": "TWICE", "uri": "", "external_urls": null, "genres": null, "images": null, "popularity": 0, "followers": {"total": 0}}, "score": 0.95, "similarity": 1, "exact": true, "noise": false},
+					{"item": {"id": "", "type": "artist", "name": "TWICE Tribute Band", "uri": "", "external_urls": null, "genres": null, "images": null, "popularity": 0, "followers": {"total": 0}}, "score": 0.1, "similarity": 0.278, "exact": false, "noise": true}
+				]
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/search/artist/twice?"+tt.rawQuery, nil)
+			ctx.Params = gin.Params{
+				{Key: "type", Value: "artist"},
+				{Key: "query", Value: "/twice"},
+			}
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+
+			if tt.expectedMatchOpts != nil {
+				mockService.On("Search", mock.Anything, "twice", "artist", appspotify.SearchOptions{}, *tt.expectedMatchOpts).
+					Return(tt.serviceMatch, tt.serviceErr).
+					Once()
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.Search(ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestSpotifyHandler_SearchMarket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		rawQuery       string
+		acceptLanguage string
+		expectedOpts   appspotify.SearchOptions
+	}{
+		{
+			name:         "no market",
+			expectedOpts: appspotify.SearchOptions{},
+		},
+		{
+			name:           "from Accept-Language",
+			acceptLanguage: "fr-FR,fr;q=0.9,en-US;q=0.8",
+			expectedOpts:   appspotify.SearchOptions{Market: "FR", Locale: "fr_FR"},
+		},
+		{
+			name:           "language without region",
+			acceptLanguage: "ja,en-GB;q=0.5",
+			expectedOpts:   appspotify.SearchOptions{Market: "GB", Locale: "ja"},
+		},
+		{
+			name:           "explicit market",
+			rawQuery:       "market=KR",
+			acceptLanguage: "fr-FR",
+			expectedOpts:   appspotify.SearchOptions{Market: "KR", Locale: "fr_FR"},
+		},
+		{
+			name:           "explicit locale",
+			rawQuery:       "locale=ko_KR",
+			acceptLanguage: "*",
+			expectedOpts:   appspotify.SearchOptions{Locale: "ko_KR"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/search/artist/twice?"+tt.rawQuery, nil)
+			ctx.Request.Header.Set("Accept-Language", tt.acceptLanguage)
+			ctx.Params = gin.Params{
+				{Key: "type", Value: "artist"},
+				{Key: "query", Value: "/twice"},
+			}
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+
+			mockService.On("Search", mock.Anything, "twice", "artist", tt.expectedOpts, appspotify.MatchOptions{}).
+				Return(&appspotify.Match{Item: &domain.Artist{Object: domain.Object{Type: "artist", Name: "TWICE"}}}, nil).
+				Once()
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.Search(ctx)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		})
+	}
+}
+
+func TestSpotifyHandler_SearchFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	twice := &domain.Artist{
+		Object: domain.Object{
+			ID:           "7n2Ycct7Beij7Dj7meI4X0",
+			Type:         "artist",
+			Name:         "TWICE",
+			ExternalURLs: map[string]string{"spotify": "https://open.spotify.com/artist/7n2Ycct7Beij7Dj7meI4X0"},
+		},
+		Images: []domain.Image{
+			{URL: "https://i.scdn.co/image/large", Height: 640, Width: 640},
+			{URL: "https://i.scdn.co/image/small", Height: 64, Width: 64},
+		},
+		Popularity: 75,
+	}
+	fancy := &domain.Track{
+		Object:           domain.Object{ID: "track", Type: "track", Name: "FANCY"},
+		Artists:          []domain.SimpleArtist{{Object: domain.Object{ID: "7n2Ycct7Beij7Dj7meI4X0", Type: "artist", Name: "TWICE"}}},
+		AvailableMarkets: []string{"FR", "KR", "US"},
+	}
+
+	tests := []struct {
+		name           string
+		pathType       string
+		rawQuery       string
+		setup          func(mockService *mocks.MockSpotifyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:     "single result",
+			pathType: "artist",
+			rawQuery: "fields=id,name,images.url,external_urls",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("Search", mock.Anything, "twice", "artist", appspotify.SearchOptions{}, appspotify.MatchOptions{}).
+					Return(&appspotify.Match{Item: twice, Confidence: 1}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"confidence": 1,
+				"id": "7n2Ycct7Beij7Dj7meI4X0",
+				"name": "TWICE",
+				"images": [{"url": "https://i.scdn.co/image/large"}, {"url": "https://i.scdn.co/image/small"}],
+				"external_urls": {"spotify": "https://open.spotify.com/artist/7n2Ycct7Beij7Dj7meI4X0"}
+			}`,
+		},
+		{
+			name:     "page with grouped fields",
+			pathType: "track",
+			rawQuery: "limit=1&fields=name,artists(id,name)",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("SearchPage", mock.Anything, "twice", "track", appspotify.SearchOptions{Limit: 1}).
+					Return(&appspotify.SearchPage{Items: []domain.Item{fancy}, Total: 1, Limit: 1}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"items": [{"name": "FANCY", "artists": [{"id": "7n2Ycct7Beij7Dj7meI4X0", "name": "TWICE"}]}],
+				"total": 1, "limit": 1, "offset": 0, "next": null, "previous": null
+			}`,
+		},
+		{
+			name:     "multiple types",
+			pathType: "artist,track",
+			rawQuery: "fields=name",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("BestMatches", mock.Anything, "twice", []string{"artist", "track"}, appspotify.SearchOptions{}, appspotify.MatchOptions{}).
+					Return(map[string]*appspotify.Match{
+						"artist": {Item: twice, Confidence: 1},
+						"track":  {Item: fancy, Confidence: 0.8},
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"artist": {"name": "TWICE", "confidence": 1}, "track": {"name": "FANCY", "confidence": 0.8}}`,
+		},
+		{
+			name:           "unknown field",
+			pathType:       "artist",
+			rawQuery:       "fields=name,available_markets",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unbalanced parenthesis",
+			pathType:       "track",
+			rawQuery:       "fields=artists(name",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/search/"+tt.pathType+"/twice?"+tt.rawQuery, nil)
+			ctx.Params = gin.Params{
+				{Key: "type", Value: tt.pathType},
+				{Key: "query", Value: "/twice"},
+			}
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+			if tt.setup != nil {
+				tt.setup(mockService)
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.Search(ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				var payload struct {
+					Error       string   `json:"error"`
+					ValidFields []string `json:"valid_fields"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &payload))
+				assert.NotEmpty(t, payload.Error)
+				assert.Contains(t, payload.ValidFields, "name")
+				return
+			}
+
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
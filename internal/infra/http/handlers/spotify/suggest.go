@@ -0,0 +1,54 @@
+package spotify
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/gin-gonic/gin"
+)
+
+// clientIDHeader identifies the client whose suggestions are debounced, such
+// as an app install or a browser tab.
+const clientIDHeader = "X-Client-ID"
+
+// Suggest responds with suggestions for a partially typed query:
+// {"suggestions": [{"type": "artist", "id": "...", "name": "...", "image": "..."}]}
+// A debounced query superseded by a newer one of the same client gets a
+// 204 No Content.
+func (h *SpotifyHandler) Suggest(c *gin.Context) {
+	ctx, span := h.tracer.Start(c.Request.Context(), "SpotifyHandler.Suggest")
+	defer span.End()
+
+	qTypes := parseSearchTypes(c.Param("type"))
+	if len(qTypes) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type is required"})
+		return
+	}
+
+	opts := appspotify.SuggestOptions{
+		Market: regionalOptions(c).Market,
+		Client: c.GetHeader(clientIDHeader),
+	}
+	if limit, ok := c.GetQuery("limit"); ok {
+		var err error
+		opts.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be an integer"})
+			return
+		}
+	}
+
+	suggestions, err := h.spotifySearchService.Suggest(ctx, c.Query("q"), qTypes, opts)
+	if errors.Is(err, appspotify.ErrSuperseded) {
+		c.AbortWithStatus(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"suggestions": suggestions})
+}
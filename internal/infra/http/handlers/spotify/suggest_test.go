@@ -0,0 +1,107 @@
+package spotify_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSpotifyHandler_Suggest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	twice := appspotify.Suggestion{Type: "artist", ID: "7n2Ycct7Beij7Dj7meI4X0", Name: "TWICE", Image: "https://i.scdn.co/160"}
+
+	tests := []struct {
+		name           string
+		path           string
+		searchType     string
+		clientID       string
+		setup          func(mockService *mocks.MockSpotifyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:       "suggestions",
+			path:       "/suggest/artist,track?q=twi&limit=3&market=KR",
+			searchType: "artist,track",
+			clientID:   "tab-1",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("Suggest", mock.Anything, "twi", []string{"artist", "track"}, appspotify.SuggestOptions{Limit: 3, Market: "KR", Client: "tab-1"}).
+					Return([]appspotify.Suggestion{twice}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"suggestions": [{"type": "artist", "id": "7n2Ycct7Beij7Dj7meI4X0", "name": "TWICE", "image": "https://i.scdn.co/160"}]}`,
+		},
+		{
+			name:       "superseded",
+			path:       "/suggest/artist?q=tw",
+			searchType: "artist",
+			clientID:   "tab-1",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("Suggest", mock.Anything, "tw", []string{"artist"}, appspotify.SuggestOptions{Client: "tab-1"}).
+					Return(nil, appspotify.ErrSuperseded).
+					Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:       "missing query",
+			path:       "/suggest/artist",
+			searchType: "artist",
+			setup: func(mockService *mocks.MockSpotifyService) {
+				mockService.On("Suggest", mock.Anything, "", []string{"artist"}, appspotify.SuggestOptions{}).
+					Return(nil, appspotify.ErrInvalidQuery).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid query"}`,
+		},
+		{
+			name:           "invalid limit",
+			path:           "/suggest/artist?q=tw&limit=all",
+			searchType:     "artist",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "limit must be an integer"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.clientID != "" {
+				ctx.Request.Header.Set("X-Client-ID", tt.clientID)
+			}
+			ctx.Params = gin.Params{{Key: "type", Value: tt.searchType}}
+
+			mockService := &mocks.MockSpotifyService{}
+			t.Cleanup(func() {
+				mockService.AssertExpectations(t)
+			})
+			if tt.setup != nil {
+				tt.setup(mockService)
+			}
+
+			h := handler.New(otel.Tracer("test"), mockService)
+			h.Suggest(ctx)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+			} else {
+				assert.Empty(t, recorder.Body.String())
+			}
+		})
+	}
+}
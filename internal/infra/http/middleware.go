@@ -0,0 +1,106 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/gin-gonic/gin"
+)
+
+// bypassNegativeCache lets clients of the trusted networks search Spotify
+// again for queries cached without results, by sending Cache-Control:
+// no-cache.
+func bypassNegativeCache(trustedNetworks []*net.IPNet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if hasNoCache(c.GetHeader("Cache-Control")) && trusted(c, trustedNetworks) {
+			c.Request = c.Request.WithContext(appspotify.WithoutNegativeCache(c.Request.Context()))
+		}
+
+		c.Next()
+	}
+}
+
+// trustedOnly restricts the routes to the clients of the trusted networks,
+// which are all denied when there are none.
+func trustedOnly(trustedNetworks []*net.IPNet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !trusted(c, trustedNetworks) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// trusted tells whether the client belongs to the trusted networks. The
+// address of the direct peer is used, as forwarding headers can be forged.
+func trusted(c *gin.Context, trustedNetworks []*net.IPNet) bool {
+	ip := net.ParseIP(c.RemoteIP())
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range trustedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheStatus records the cache status of each request, and reports stale
+// results with an X-Cache: STALE header.
+func cacheStatus() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, status := appspotify.WithCacheStatus(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		c.Writer = &cacheStatusWriter{ResponseWriter: c.Writer, status: status}
+
+		c.Next()
+	}
+}
+
+// cacheStatusWriter sets the X-Cache header before the status is written, as
+// headers can't be changed once the handler wrote the body.
+type cacheStatusWriter struct {
+	gin.ResponseWriter
+	status *appspotify.CacheStatus
+}
+
+func (w *cacheStatusWriter) WriteHeader(code int) {
+	w.setHeader()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheStatusWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheStatusWriter) Write(data []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheStatusWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *cacheStatusWriter) setHeader() {
+	if !w.Written() && w.status.Stale() {
+		w.Header().Set("X-Cache", "STALE")
+	}
+}
+
+func hasNoCache(cacheControl string) bool {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-cache") {
+			return true
+		}
+	}
+	return false
+}
@@ -20,6 +20,501 @@ func (_m *MockSpotifyHandler) EXPECT() *MockSpotifyHandler_Expecter {
 	return &MockSpotifyHandler_Expecter{mock: &_m.Mock}
 }
 
+// Album provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) Album(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_Album_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Album'
+type MockSpotifyHandler_Album_Call struct {
+	*mock.Call
+}
+
+// Album is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) Album(ctx interface{}) *MockSpotifyHandler_Album_Call {
+	return &MockSpotifyHandler_Album_Call{Call: _e.mock.On("Album", ctx)}
+}
+
+func (_c *MockSpotifyHandler_Album_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_Album_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Album_Call) Return() *MockSpotifyHandler_Album_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Album_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_Album_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AlbumTracks provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) AlbumTracks(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_AlbumTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlbumTracks'
+type MockSpotifyHandler_AlbumTracks_Call struct {
+	*mock.Call
+}
+
+// AlbumTracks is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) AlbumTracks(ctx interface{}) *MockSpotifyHandler_AlbumTracks_Call {
+	return &MockSpotifyHandler_AlbumTracks_Call{Call: _e.mock.On("AlbumTracks", ctx)}
+}
+
+func (_c *MockSpotifyHandler_AlbumTracks_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_AlbumTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_AlbumTracks_Call) Return() *MockSpotifyHandler_AlbumTracks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_AlbumTracks_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_AlbumTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Albums provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) Albums(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_Albums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Albums'
+type MockSpotifyHandler_Albums_Call struct {
+	*mock.Call
+}
+
+// Albums is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) Albums(ctx interface{}) *MockSpotifyHandler_Albums_Call {
+	return &MockSpotifyHandler_Albums_Call{Call: _e.mock.On("Albums", ctx)}
+}
+
+func (_c *MockSpotifyHandler_Albums_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_Albums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Albums_Call) Return() *MockSpotifyHandler_Albums_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Albums_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_Albums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Artist provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) Artist(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_Artist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Artist'
+type MockSpotifyHandler_Artist_Call struct {
+	*mock.Call
+}
+
+// Artist is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) Artist(ctx interface{}) *MockSpotifyHandler_Artist_Call {
+	return &MockSpotifyHandler_Artist_Call{Call: _e.mock.On("Artist", ctx)}
+}
+
+func (_c *MockSpotifyHandler_Artist_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_Artist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Artist_Call) Return() *MockSpotifyHandler_Artist_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Artist_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_Artist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArtistAlbums provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) ArtistAlbums(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_ArtistAlbums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArtistAlbums'
+type MockSpotifyHandler_ArtistAlbums_Call struct {
+	*mock.Call
+}
+
+// ArtistAlbums is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) ArtistAlbums(ctx interface{}) *MockSpotifyHandler_ArtistAlbums_Call {
+	return &MockSpotifyHandler_ArtistAlbums_Call{Call: _e.mock.On("ArtistAlbums", ctx)}
+}
+
+func (_c *MockSpotifyHandler_ArtistAlbums_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_ArtistAlbums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_ArtistAlbums_Call) Return() *MockSpotifyHandler_ArtistAlbums_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_ArtistAlbums_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_ArtistAlbums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArtistProfile provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) ArtistProfile(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_ArtistProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArtistProfile'
+type MockSpotifyHandler_ArtistProfile_Call struct {
+	*mock.Call
+}
+
+// ArtistProfile is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) ArtistProfile(ctx interface{}) *MockSpotifyHandler_ArtistProfile_Call {
+	return &MockSpotifyHandler_ArtistProfile_Call{Call: _e.mock.On("ArtistProfile", ctx)}
+}
+
+func (_c *MockSpotifyHandler_ArtistProfile_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_ArtistProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_ArtistProfile_Call) Return() *MockSpotifyHandler_ArtistProfile_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_ArtistProfile_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_ArtistProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArtistTopTracks provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) ArtistTopTracks(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_ArtistTopTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArtistTopTracks'
+type MockSpotifyHandler_ArtistTopTracks_Call struct {
+	*mock.Call
+}
+
+// ArtistTopTracks is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) ArtistTopTracks(ctx interface{}) *MockSpotifyHandler_ArtistTopTracks_Call {
+	return &MockSpotifyHandler_ArtistTopTracks_Call{Call: _e.mock.On("ArtistTopTracks", ctx)}
+}
+
+func (_c *MockSpotifyHandler_ArtistTopTracks_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_ArtistTopTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_ArtistTopTracks_Call) Return() *MockSpotifyHandler_ArtistTopTracks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_ArtistTopTracks_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_ArtistTopTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Artists provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) Artists(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_Artists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Artists'
+type MockSpotifyHandler_Artists_Call struct {
+	*mock.Call
+}
+
+// Artists is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) Artists(ctx interface{}) *MockSpotifyHandler_Artists_Call {
+	return &MockSpotifyHandler_Artists_Call{Call: _e.mock.On("Artists", ctx)}
+}
+
+func (_c *MockSpotifyHandler_Artists_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_Artists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Artists_Call) Return() *MockSpotifyHandler_Artists_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Artists_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_Artists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BatchSearch provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) BatchSearch(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_BatchSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchSearch'
+type MockSpotifyHandler_BatchSearch_Call struct {
+	*mock.Call
+}
+
+// BatchSearch is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) BatchSearch(ctx interface{}) *MockSpotifyHandler_BatchSearch_Call {
+	return &MockSpotifyHandler_BatchSearch_Call{Call: _e.mock.On("BatchSearch", ctx)}
+}
+
+func (_c *MockSpotifyHandler_BatchSearch_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_BatchSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_BatchSearch_Call) Return() *MockSpotifyHandler_BatchSearch_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_BatchSearch_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_BatchSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BumpCacheGeneration provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) BumpCacheGeneration(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_BumpCacheGeneration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BumpCacheGeneration'
+type MockSpotifyHandler_BumpCacheGeneration_Call struct {
+	*mock.Call
+}
+
+// BumpCacheGeneration is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) BumpCacheGeneration(ctx interface{}) *MockSpotifyHandler_BumpCacheGeneration_Call {
+	return &MockSpotifyHandler_BumpCacheGeneration_Call{Call: _e.mock.On("BumpCacheGeneration", ctx)}
+}
+
+func (_c *MockSpotifyHandler_BumpCacheGeneration_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_BumpCacheGeneration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_BumpCacheGeneration_Call) Return() *MockSpotifyHandler_BumpCacheGeneration_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_BumpCacheGeneration_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_BumpCacheGeneration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lookup provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) Lookup(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_Lookup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lookup'
+type MockSpotifyHandler_Lookup_Call struct {
+	*mock.Call
+}
+
+// Lookup is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) Lookup(ctx interface{}) *MockSpotifyHandler_Lookup_Call {
+	return &MockSpotifyHandler_Lookup_Call{Call: _e.mock.On("Lookup", ctx)}
+}
+
+func (_c *MockSpotifyHandler_Lookup_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_Lookup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Lookup_Call) Return() *MockSpotifyHandler_Lookup_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Lookup_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_Lookup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LookupISRC provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) LookupISRC(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_LookupISRC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupISRC'
+type MockSpotifyHandler_LookupISRC_Call struct {
+	*mock.Call
+}
+
+// LookupISRC is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) LookupISRC(ctx interface{}) *MockSpotifyHandler_LookupISRC_Call {
+	return &MockSpotifyHandler_LookupISRC_Call{Call: _e.mock.On("LookupISRC", ctx)}
+}
+
+func (_c *MockSpotifyHandler_LookupISRC_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_LookupISRC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_LookupISRC_Call) Return() *MockSpotifyHandler_LookupISRC_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_LookupISRC_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_LookupISRC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LookupUPC provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) LookupUPC(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_LookupUPC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupUPC'
+type MockSpotifyHandler_LookupUPC_Call struct {
+	*mock.Call
+}
+
+// LookupUPC is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) LookupUPC(ctx interface{}) *MockSpotifyHandler_LookupUPC_Call {
+	return &MockSpotifyHandler_LookupUPC_Call{Call: _e.mock.On("LookupUPC", ctx)}
+}
+
+func (_c *MockSpotifyHandler_LookupUPC_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_LookupUPC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_LookupUPC_Call) Return() *MockSpotifyHandler_LookupUPC_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_LookupUPC_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_LookupUPC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RelatedArtists provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) RelatedArtists(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_RelatedArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RelatedArtists'
+type MockSpotifyHandler_RelatedArtists_Call struct {
+	*mock.Call
+}
+
+// RelatedArtists is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) RelatedArtists(ctx interface{}) *MockSpotifyHandler_RelatedArtists_Call {
+	return &MockSpotifyHandler_RelatedArtists_Call{Call: _e.mock.On("RelatedArtists", ctx)}
+}
+
+func (_c *MockSpotifyHandler_RelatedArtists_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_RelatedArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_RelatedArtists_Call) Return() *MockSpotifyHandler_RelatedArtists_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_RelatedArtists_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_RelatedArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resolve provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) Resolve(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockSpotifyHandler_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) Resolve(ctx interface{}) *MockSpotifyHandler_Resolve_Call {
+	return &MockSpotifyHandler_Resolve_Call{Call: _e.mock.On("Resolve", ctx)}
+}
+
+func (_c *MockSpotifyHandler_Resolve_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Resolve_Call) Return() *MockSpotifyHandler_Resolve_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Resolve_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_Resolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Search provides a mock function with given fields: ctx
 func (_m *MockSpotifyHandler) Search(ctx *gin.Context) {
 	_m.Called(ctx)
@@ -53,6 +548,105 @@ func (_c *MockSpotifyHandler_Search_Call) RunAndReturn(run func(*gin.Context)) *
 	return _c
 }
 
+// Suggest provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) Suggest(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_Suggest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Suggest'
+type MockSpotifyHandler_Suggest_Call struct {
+	*mock.Call
+}
+
+// Suggest is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) Suggest(ctx interface{}) *MockSpotifyHandler_Suggest_Call {
+	return &MockSpotifyHandler_Suggest_Call{Call: _e.mock.On("Suggest", ctx)}
+}
+
+func (_c *MockSpotifyHandler_Suggest_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_Suggest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Suggest_Call) Return() *MockSpotifyHandler_Suggest_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Suggest_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_Suggest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Track provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) Track(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_Track_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Track'
+type MockSpotifyHandler_Track_Call struct {
+	*mock.Call
+}
+
+// Track is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) Track(ctx interface{}) *MockSpotifyHandler_Track_Call {
+	return &MockSpotifyHandler_Track_Call{Call: _e.mock.On("Track", ctx)}
+}
+
+func (_c *MockSpotifyHandler_Track_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_Track_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Track_Call) Return() *MockSpotifyHandler_Track_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Track_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_Track_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tracks provides a mock function with given fields: ctx
+func (_m *MockSpotifyHandler) Tracks(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSpotifyHandler_Tracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tracks'
+type MockSpotifyHandler_Tracks_Call struct {
+	*mock.Call
+}
+
+// Tracks is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSpotifyHandler_Expecter) Tracks(ctx interface{}) *MockSpotifyHandler_Tracks_Call {
+	return &MockSpotifyHandler_Tracks_Call{Call: _e.mock.On("Tracks", ctx)}
+}
+
+func (_c *MockSpotifyHandler_Tracks_Call) Run(run func(ctx *gin.Context)) *MockSpotifyHandler_Tracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Tracks_Call) Return() *MockSpotifyHandler_Tracks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSpotifyHandler_Tracks_Call) RunAndReturn(run func(*gin.Context)) *MockSpotifyHandler_Tracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockSpotifyHandler creates a new instance of MockSpotifyHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockSpotifyHandler(t interface {
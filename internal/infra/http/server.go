@@ -27,12 +27,37 @@ func New(cfg Config, sh SpotifyHandler) (*Server, error) {
 		engine.Use(gin.Logger())
 		engine.Use(otelgin.Middleware("spotify-search-proxy"))
 	}
+	engine.Use(cacheStatus())
+	if len(cfg.TrustedNetworks) > 0 {
+		engine.Use(bypassNegativeCache(cfg.TrustedNetworks))
+	}
 
 	engine.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
 	engine.GET("/search/:type/*query", sh.Search)
+	engine.POST("/search/batch", sh.BatchSearch)
+	engine.GET("/suggest/:type", sh.Suggest)
+	engine.GET("/lookup/:type", sh.Lookup)
+	engine.GET("/lookup/isrc/:isrc", sh.LookupISRC)
+	engine.GET("/lookup/upc/:upc", sh.LookupUPC)
+
+	engine.GET("/artists/:id", sh.Artist)
+	engine.GET("/albums/:id", sh.Album)
+	engine.GET("/tracks/:id", sh.Track)
+	engine.GET("/artists/:id/top-tracks", sh.ArtistTopTracks)
+	engine.GET("/artists/:id/albums", sh.ArtistAlbums)
+	engine.GET("/artists/:id/related", sh.RelatedArtists)
+	engine.GET("/artists/:id/profile", sh.ArtistProfile)
+	engine.GET("/albums/:id/tracks", sh.AlbumTracks)
+	engine.GET("/artists", sh.Artists)
+	engine.GET("/albums", sh.Albums)
+	engine.GET("/tracks", sh.Tracks)
+	engine.GET("/resolve", sh.Resolve)
+
+	admin := engine.Group("/admin", trustedOnly(cfg.TrustedNetworks))
+	admin.POST("/cache/generation", sh.BumpCacheGeneration)
 
 	internalServer := &http.Server{
 		Addr:              fmt.Sprintf("0.0.0.0:%d", httpPort),
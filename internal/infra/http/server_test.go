@@ -0,0 +1,236 @@
+package server_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify/mocks"
+	server "github.com/angristan/spotify-search-proxy/internal/infra/http"
+	handler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+// newServer returns a server in front of the service, with its cache and
+// Spotify client mocked.
+func newServer(t *testing.T, trustedNetworks []*net.IPNet, config appspotify.Config) (*server.Server, *mocks.MockSpotifyClient, *mocks.MockCache) {
+	gin.SetMode(gin.TestMode)
+
+	mockedSpotifyClient := &mocks.MockSpotifyClient{}
+	mockedCache := &mocks.MockCache{}
+	t.Cleanup(func() {
+		mockedCache.AssertExpectations(t)
+		mockedSpotifyClient.AssertExpectations(t)
+	})
+
+	tracer := otel.Tracer("test")
+	service := appspotify.New(tracer, mockedSpotifyClient, mockedCache, config)
+
+	serverConfig := server.NewConfig("1323", true)
+	serverConfig.TrustedNetworks = trustedNetworks
+
+	srv, err := server.New(serverConfig, handler.New(tracer, service))
+	require.NoError(t, err)
+
+	return srv, mockedSpotifyClient, mockedCache
+}
+
+func TestServer_CacheStatus(t *testing.T) {
+	entry := func(staleAt time.Time) string {
+		return fmt.Sprintf(`{"stale_at": %d, "value": {"items": [{"type": "artist", "name": "TWICE"}], "total": 1}}`, staleAt.Unix())
+	}
+
+	t.Run("stale", func(t *testing.T) {
+		srv, mockedSpotifyClient, mockedCache := newServer(t, nil, appspotify.Config{
+			TTLs: appspotify.TTLPolicy{Stale: time.Hour},
+		})
+
+		refreshed := make(chan struct{})
+		mockedCache.On("Get", mock.Anything, mock.Anything).
+			Return(entry(time.Now().Add(-time.Minute)), nil).
+			Once()
+		mockedSpotifyClient.On("Search", mock.Anything, "twice", []string{"artist"}, mock.Anything).
+			Return(nil, errors.New("service unavailable")).
+			Run(func(mock.Arguments) { close(refreshed) }).
+			Once()
+
+		w := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search/artist/twice", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "STALE", w.Header().Get("X-Cache"))
+
+		select {
+		case <-refreshed:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the background refresh")
+		}
+	})
+
+	t.Run("fresh", func(t *testing.T) {
+		srv, _, mockedCache := newServer(t, nil, appspotify.Config{
+			TTLs: appspotify.TTLPolicy{Stale: time.Hour},
+		})
+
+		mockedCache.On("Get", mock.Anything, mock.Anything).
+			Return(entry(time.Now().Add(time.Hour)), nil).
+			Once()
+
+		w := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search/artist/twice", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Values("X-Cache"))
+	})
+}
+
+func TestServer_Admin(t *testing.T) {
+	_, trustedNetwork, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		trustedNetworks []*net.IPNet
+		remoteAddr      string
+		forwardedFor    string
+		expectedStatus  int
+	}{
+		{
+			name:            "trusted",
+			trustedNetworks: []*net.IPNet{trustedNetwork},
+			remoteAddr:      "10.1.2.3:4321",
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "untrusted",
+			trustedNetworks: []*net.IPNet{trustedNetwork},
+			remoteAddr:      "192.0.2.1:4321",
+			expectedStatus:  http.StatusForbidden,
+		},
+		{
+			name:           "no trusted networks",
+			remoteAddr:     "10.1.2.3:4321",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:            "spoofed X-Forwarded-For",
+			trustedNetworks: []*net.IPNet{trustedNetwork},
+			remoteAddr:      "192.0.2.1:4321",
+			forwardedFor:    "10.1.2.3",
+			expectedStatus:  http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _, mockedCache := newServer(t, tt.trustedNetworks, appspotify.Config{})
+
+			if tt.expectedStatus == http.StatusOK {
+				mockedCache.On("Get", mock.Anything, "spotify:generation").
+					Return("", appspotify.ErrCacheMiss).
+					Once()
+				mockedCache.On("Set", mock.Anything, "spotify:generation", []byte("1"), mock.Anything).
+					Return(nil).
+					Once()
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/admin/cache/generation", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+
+			w := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, `{"generation": 1}`, w.Body.String())
+			} else {
+				assert.JSONEq(t, `{"error": "forbidden"}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_BypassNegativeCache(t *testing.T) {
+	_, trustedNetwork, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		trustedNetworks []*net.IPNet
+		remoteAddr      string
+		cacheControl    string
+		bypassed        bool
+	}{
+		{
+			name:            "trusted",
+			trustedNetworks: []*net.IPNet{trustedNetwork},
+			remoteAddr:      "10.1.2.3:4321",
+			cacheControl:    "max-age=0, no-cache",
+			bypassed:        true,
+		},
+		{
+			name:            "trusted without no-cache",
+			trustedNetworks: []*net.IPNet{trustedNetwork},
+			remoteAddr:      "10.1.2.3:4321",
+		},
+		{
+			name:            "untrusted",
+			trustedNetworks: []*net.IPNet{trustedNetwork},
+			remoteAddr:      "192.0.2.1:4321",
+			cacheControl:    "no-cache",
+		},
+		{
+			name:         "no trusted networks",
+			remoteAddr:   "10.1.2.3:4321",
+			cacheControl: "no-cache",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, mockedSpotifyClient, mockedCache := newServer(t, tt.trustedNetworks, appspotify.Config{})
+
+			mockedCache.On("Get", mock.Anything, mock.Anything).
+				Return(domain.NotFoundCacheValue, nil).
+				Once()
+			if tt.bypassed {
+				mockedSpotifyClient.On("Search", mock.Anything, "twice", []string{"artist"}, mock.Anything).
+					Return(map[string]*appspotify.SearchPage{
+						"artist": appspotify.NewSearchPage([]domain.Item{&domain.Artist{Object: domain.Object{Type: "artist", Name: "TWICE"}}}, 1, appspotify.SearchOptions{}),
+					}, nil).
+					Once()
+				mockedCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/search/artist/twice", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.cacheControl != "" {
+				r.Header.Set("Cache-Control", tt.cacheControl)
+			}
+
+			w := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(w, r)
+
+			if tt.bypassed {
+				assert.Equal(t, http.StatusOK, w.Code)
+			} else {
+				assert.Equal(t, http.StatusNotFound, w.Code)
+			}
+		})
+	}
+}
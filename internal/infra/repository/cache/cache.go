@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	spotifyService "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/disk"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/memory"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/noop"
+	redisCache "github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/redis"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/tiered"
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	goRedis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var ErrUnknownBackend = errors.New("unknown cache backend")
+
+// Config holds the settings of all the backends, each of them only reading
+// its own.
+type Config struct {
+	Tracer trace.Tracer
+
+	RedisAddr     string
+	RedisUsername string
+	RedisPassword string
+	// RedisCompression compresses the values stored in Redis
+	RedisCompression redisCache.Compression
+
+	Memory memory.Config
+
+	// DiskPath is the file of the disk backend
+	DiskPath string
+}
+
+// Backend is a cache, along with what the proxy can build on top of it.
+type Backend struct {
+	Cache spotifyService.Cache
+	// Locker is only set by the backends shared between instances
+	Locker spotifyService.Locker
+	// Invalidator is only set by the backends shared between instances, in
+	// front of which an in-process tier is worth it
+	Invalidator tiered.Invalidator
+	// Sweeper is only set by the backends that can delete keys by pattern
+	Sweeper spotifyService.Sweeper
+	// Close releases the connections or files of the backend
+	Close func() error
+}
+
+// Factory creates a backend from the configuration.
+type Factory func(ctx context.Context, config Config) (*Backend, error)
+
+var factories = map[string]Factory{
+	"redis":  newRedisBackend,
+	"memory": newMemoryBackend,
+	"disk":   newDiskBackend,
+	"noop":   newNoopBackend,
+}
+
+// Register adds a backend, or replaces the one with the same name. It must
+// be called before New, e.g. from an init function.
+func Register(name string, factory Factory) {
+	factories[name] = factory
+}
+
+// Backends returns the names of the registered backends, sorted.
+func Backends() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the backend with the given name.
+func New(ctx context.Context, name string, config Config) (*Backend, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q, expected one of %s", ErrUnknownBackend, name, strings.Join(Backends(), ", "))
+	}
+
+	backend, err := factory(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("%s cache backend: %w", name, err)
+	}
+	return backend, nil
+}
+
+func newRedisBackend(_ context.Context, config Config) (*Backend, error) {
+	if config.RedisAddr == "" {
+		return nil, errors.New("no Redis address")
+	}
+
+	redisClient := goRedis.NewClient(&goRedis.Options{
+		Addr:     config.RedisAddr,
+		Password: config.RedisPassword,
+		Username: config.RedisUsername,
+	})
+
+	if err := redisotel.InstrumentTracing(redisClient); err != nil {
+		_ = redisClient.Close()
+		return nil, fmt.Errorf("redis tracing: %w", err)
+	}
+
+	cache, err := redisCache.New(config.Tracer, redisClient, redisCache.Config{
+		Compression: config.RedisCompression,
+	})
+	if err != nil {
+		_ = redisClient.Close()
+		return nil, err
+	}
+
+	invalidator, err := redisCache.NewInvalidator(config.Tracer, redisClient)
+	if err != nil {
+		_ = redisClient.Close()
+		return nil, err
+	}
+
+	return &Backend{
+		Cache:       cache,
+		Locker:      cache,
+		Invalidator: invalidator,
+		Sweeper:     cache,
+		Close:       redisClient.Close,
+	}, nil
+}
+
+func newMemoryBackend(_ context.Context, config Config) (*Backend, error) {
+	return &Backend{
+		Cache: memory.New(config.Tracer, config.Memory),
+		Close: func() error { return nil },
+	}, nil
+}
+
+func newDiskBackend(_ context.Context, config Config) (*Backend, error) {
+	if config.DiskPath == "" {
+		return nil, errors.New("no file path")
+	}
+
+	cache, err := disk.New(config.Tracer, config.DiskPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Backend{
+		Cache: cache,
+		Close: cache.Close,
+	}, nil
+}
+
+func newNoopBackend(_ context.Context, _ Config) (*Backend, error) {
+	return &Backend{
+		Cache: noop.New(),
+		Close: func() error { return nil },
+	}, nil
+}
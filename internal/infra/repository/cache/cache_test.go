@@ -0,0 +1,181 @@
+package cache_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache"
+	redisCache "github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+// backendTest sets up a backend for the conformance suite.
+type backendTest struct {
+	// setup returns the configuration of a new backend, and a function that
+	// lets time pass for the expiration of its keys
+	setup func(t *testing.T) (cache.Config, func(d time.Duration))
+	// discards tells whether the backend never returns the cached values
+	discards bool
+}
+
+func local(t *testing.T) (cache.Config, func(d time.Duration)) {
+	return cache.Config{}, time.Sleep
+}
+
+func TestBackends(t *testing.T) {
+	ctx := context.Background()
+
+	backends := map[string]backendTest{
+		"redis": {
+			setup: func(t *testing.T) (cache.Config, func(d time.Duration)) {
+				server := miniredis.RunT(t)
+				// miniredis only expires keys when told that time passed
+				return cache.Config{
+					RedisAddr:        server.Addr(),
+					RedisCompression: redisCache.Compression{Algorithm: redisCache.CompressionGzip},
+				}, server.FastForward
+			},
+		},
+		"memory": {setup: local},
+		"disk": {
+			setup: func(t *testing.T) (cache.Config, func(d time.Duration)) {
+				return cache.Config{DiskPath: filepath.Join(t.TempDir(), "cache.db")}, time.Sleep
+			},
+		},
+		"noop": {setup: local, discards: true},
+	}
+
+	assert.ElementsMatch(t, cache.Backends(), func() []string {
+		names := []string{}
+		for name := range backends {
+			names = append(names, name)
+		}
+		return names
+	}(), "every backend must pass the conformance suite")
+
+	for _, name := range cache.Backends() {
+		bt, ok := backends[name]
+		if !ok {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			newCache := func(t *testing.T) (spotify.Cache, func(d time.Duration)) {
+				config, elapse := bt.setup(t)
+				config.Tracer = otel.Tracer("test")
+
+				backend, err := cache.New(ctx, name, config)
+				require.NoError(t, err)
+				t.Cleanup(func() { assert.NoError(t, backend.Close()) })
+
+				return backend.Cache, elapse
+			}
+
+			// expected returns the value a backend is expected to return
+			expected := func(value string) string {
+				if bt.discards {
+					return ""
+				}
+				return value
+			}
+
+			t.Run("get missing", func(t *testing.T) {
+				c, _ := newCache(t)
+
+				value, err := c.Get(ctx, "missing")
+				assert.ErrorIs(t, err, spotify.ErrCacheMiss)
+				assert.Empty(t, value)
+			})
+
+			t.Run("set get", func(t *testing.T) {
+				c, _ := newCache(t)
+
+				for _, value := range []string{`{"items": []}`, domain.NotFoundCacheValue, "\xff\x00binary", strings.Repeat(`{"name": "TWICE"}`, 100)} {
+					require.NoError(t, c.Set(ctx, "key", []byte(value), time.Hour))
+
+					cached, err := c.Get(ctx, "key")
+					if bt.discards {
+						assert.ErrorIs(t, err, spotify.ErrCacheMiss)
+						continue
+					}
+					require.NoError(t, err)
+					assert.Equal(t, value, cached)
+				}
+			})
+
+			t.Run("mget", func(t *testing.T) {
+				c, _ := newCache(t)
+
+				require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Hour))
+				require.NoError(t, c.MSet(ctx, map[string][]byte{"c": []byte("3"), "d": []byte("4")}, time.Hour))
+
+				values, err := c.MGet(ctx, []string{"a", "b", "c", "d"})
+				require.NoError(t, err)
+				assert.Equal(t, []string{expected("1"), "", expected("3"), expected("4")}, values)
+
+				values, err = c.MGet(ctx, nil)
+				require.NoError(t, err)
+				assert.Empty(t, values)
+
+				require.NoError(t, c.MSet(ctx, nil, time.Hour))
+			})
+
+			t.Run("expiration", func(t *testing.T) {
+				c, elapse := newCache(t)
+
+				require.NoError(t, c.Set(ctx, "expiring", []byte("value"), time.Millisecond*50))
+				require.NoError(t, c.MSet(ctx, map[string][]byte{"expiring-batch": []byte("value")}, time.Millisecond*50))
+				require.NoError(t, c.Set(ctx, "lasting", []byte("value"), time.Hour))
+				elapse(time.Millisecond * 100)
+
+				_, err := c.Get(ctx, "expiring")
+				assert.ErrorIs(t, err, spotify.ErrCacheMiss)
+
+				values, err := c.MGet(ctx, []string{"expiring", "expiring-batch", "lasting"})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"", "", expected("value")}, values)
+			})
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown backend", func(t *testing.T) {
+		_, err := cache.New(ctx, "memcached", cache.Config{Tracer: otel.Tracer("test")})
+		assert.ErrorIs(t, err, cache.ErrUnknownBackend)
+	})
+
+	t.Run("missing redis address", func(t *testing.T) {
+		_, err := cache.New(ctx, "redis", cache.Config{Tracer: otel.Tracer("test")})
+		assert.Error(t, err)
+	})
+
+	t.Run("shared backend", func(t *testing.T) {
+		server := miniredis.RunT(t)
+
+		backend, err := cache.New(ctx, "redis", cache.Config{Tracer: otel.Tracer("test"), RedisAddr: server.Addr()})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = backend.Close() })
+
+		assert.NotNil(t, backend.Locker)
+		assert.NotNil(t, backend.Invalidator)
+	})
+
+	t.Run("local backend", func(t *testing.T) {
+		backend, err := cache.New(ctx, "memory", cache.Config{Tracer: otel.Tracer("test")})
+		require.NoError(t, err)
+
+		assert.Nil(t, backend.Locker)
+		assert.Nil(t, backend.Invalidator)
+	})
+}
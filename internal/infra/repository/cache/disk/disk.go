@@ -0,0 +1,279 @@
+package disk
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	bolt "go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sweepInterval is how often the expired keys are deleted from the file.
+const sweepInterval = time.Minute * 10
+
+var bucket = []byte("cache")
+
+// DiskCache is a cache stored in a single file, which survives restarts but
+// isn't shared between instances.
+type DiskCache struct {
+	tracer trace.Tracer
+	db     *bolt.DB
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New opens the cache stored at path, creating it if needed, and deletes its
+// expired keys in the background until closed.
+func New(
+	tracer trace.Tracer,
+	path string,
+) (*DiskCache, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("bolt open %q: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("bolt create bucket: %w", err)
+	}
+
+	c := &DiskCache{
+		tracer: tracer,
+		db:     db,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go c.sweepEvery(sweepInterval)
+
+	return c, nil
+}
+
+func (c *DiskCache) Get(ctx context.Context, key string) (string, error) {
+	_, span := c.tracer.Start(ctx, "DiskCache.Get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("key", key))
+
+	var value string
+	var ok bool
+	err := c.db.View(func(tx *bolt.Tx) error {
+		value, ok = get(tx, key, time.Now())
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("bolt get %q: %w", key, err)
+	}
+	if !ok {
+		span.SetStatus(codes.Ok, "Cache miss")
+		return "", spotify.ErrCacheMiss
+	}
+
+	span.SetAttributes(
+		attribute.Int("value_length", len(value)),
+		attribute.Bool("negative", value == domain.NotFoundCacheValue),
+	)
+	span.SetStatus(codes.Ok, "Cache hit")
+	return value, nil
+}
+
+// Set caches the value for ttl, or forever when ttl is zero.
+func (c *DiskCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, span := c.tracer.Start(ctx, "DiskCache.Set")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("ttl", int64(ttl.Seconds())))
+
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(key), encode(value, ttl, time.Now()))
+	})
+	if err != nil {
+		return fmt.Errorf("bolt set %q: %w", key, err)
+	}
+	return nil
+}
+
+func (c *DiskCache) MGet(ctx context.Context, keys []string) ([]string, error) {
+	_, span := c.tracer.Start(ctx, "DiskCache.MGet")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("keys", len(keys)))
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	result := make([]string, len(keys))
+	hits, negativeHits := 0, 0
+	now := time.Now()
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		for i, key := range keys {
+			if value, ok := get(tx, key, now); ok {
+				result[i] = value
+				hits++
+				if value == domain.NotFoundCacheValue {
+					negativeHits++
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("bolt mget: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.Int("hits", hits),
+		attribute.Int("negative_hits", negativeHits),
+	)
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// MSet sets all the values in a single transaction.
+func (c *DiskCache) MSet(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	_, span := c.tracer.Start(ctx, "DiskCache.MSet")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("keys", len(values)),
+		attribute.Int64("ttl", int64(ttl.Seconds())),
+	)
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		for key, value := range values {
+			if err := b.Put([]byte(key), encode(value, ttl, now)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("bolt mset: %w", err)
+	}
+	return nil
+}
+
+// Sweep deletes the expired keys.
+func (c *DiskCache) Sweep(ctx context.Context) error {
+	_, span := c.tracer.Start(ctx, "DiskCache.Sweep")
+	defer span.End()
+
+	deleted := 0
+	now := time.Now()
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+
+		// Deleting while iterating with a cursor skips keys
+		var expired [][]byte
+		err := b.ForEach(func(key, value []byte) error {
+			if _, ok := decode(value, now); !ok {
+				expired = append(expired, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, key := range expired {
+			if err := b.Delete(key); err != nil {
+				return err
+			}
+		}
+		deleted = len(expired)
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("bolt sweep: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("deleted", deleted))
+	return nil
+}
+
+// Close stops deleting the expired keys, and closes the file.
+func (c *DiskCache) Close() error {
+	close(c.stop)
+	<-c.done
+
+	return c.db.Close()
+}
+
+func (c *DiskCache) sweepEvery(interval time.Duration) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			// Errors are recorded on the span, and the next sweep retries
+			_ = c.Sweep(context.Background())
+		}
+	}
+}
+
+// get returns the value of a key that hasn't expired. The value is copied,
+// as it is only valid during the transaction.
+func get(tx *bolt.Tx, key string, now time.Time) (string, bool) {
+	value, ok := decode(tx.Bucket(bucket).Get([]byte(key)), now)
+	if !ok {
+		return "", false
+	}
+	return string(value), true
+}
+
+// encode prefixes the value with its expiration time, in Unix nanoseconds, or
+// zero if it doesn't expire.
+func encode(value []byte, ttl time.Duration, now time.Time) []byte {
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = now.Add(ttl).UnixNano()
+	}
+
+	encoded := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(encoded, uint64(expiresAt))
+	copy(encoded[8:], value)
+	return encoded
+}
+
+// decode returns the value of an encoded value that hasn't expired.
+func decode(encoded []byte, now time.Time) ([]byte, bool) {
+	if len(encoded) < 8 {
+		return nil, false
+	}
+
+	expiresAt := int64(binary.BigEndian.Uint64(encoded))
+	if expiresAt != 0 && now.UnixNano() >= expiresAt {
+		return nil, false
+	}
+	return encoded[8:], true
+}
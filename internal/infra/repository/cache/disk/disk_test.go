@@ -0,0 +1,50 @@
+package disk_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/disk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestDiskCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reopen", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache.db")
+
+		c, err := disk.New(otel.Tracer("test"), path)
+		require.NoError(t, err)
+		require.NoError(t, c.Set(ctx, "key", []byte("value"), time.Hour))
+		require.NoError(t, c.Close())
+
+		c, err = disk.New(otel.Tracer("test"), path)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = c.Close() })
+
+		value, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("sweep", func(t *testing.T) {
+		c, err := disk.New(otel.Tracer("test"), filepath.Join(t.TempDir(), "cache.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = c.Close() })
+
+		require.NoError(t, c.MSet(ctx, map[string][]byte{"a": []byte("1"), "b": []byte("2")}, time.Millisecond*10))
+		require.NoError(t, c.Set(ctx, "c", []byte("3"), 0))
+		time.Sleep(time.Millisecond * 20)
+
+		require.NoError(t, c.Sweep(ctx))
+
+		values, err := c.MGet(ctx, []string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", "", "3"}, values)
+	})
+}
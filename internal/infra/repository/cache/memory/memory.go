@@ -0,0 +1,224 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config bounds the size of a MemoryCache. Zero values mean no limit.
+type Config struct {
+	MaxEntries int
+	// MaxBytes bounds the total length of the keys and values
+	MaxBytes int64
+}
+
+type entry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+func (e *entry) size() int64 {
+	return int64(len(e.key) + len(e.value))
+}
+
+func (e *entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// MemoryCache is an in-process cache which evicts the least recently used
+// keys once over its budget.
+type MemoryCache struct {
+	tracer trace.Tracer
+	config Config
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	bytes   int64
+}
+
+func New(
+	tracer trace.Tracer,
+	config Config,
+) *MemoryCache {
+	return &MemoryCache{
+		tracer:  tracer,
+		config:  config,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) (string, error) {
+	_, span := c.tracer.Start(ctx, "MemoryCache.Get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("key", key))
+
+	c.mu.Lock()
+	value, ok := c.get(key, time.Now())
+	c.mu.Unlock()
+
+	if !ok {
+		span.SetStatus(codes.Ok, "Cache miss")
+		return "", spotify.ErrCacheMiss
+	}
+
+	span.SetAttributes(
+		attribute.Int("value_length", len(value)),
+		attribute.Bool("negative", value == domain.NotFoundCacheValue),
+	)
+	span.SetStatus(codes.Ok, "Cache hit")
+	return value, nil
+}
+
+// Set caches the value for ttl, or until evicted when ttl is zero.
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, span := c.tracer.Start(ctx, "MemoryCache.Set")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("ttl", int64(ttl.Seconds())))
+
+	c.mu.Lock()
+	c.set(key, string(value), ttl, time.Now())
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *MemoryCache) MGet(ctx context.Context, keys []string) ([]string, error) {
+	_, span := c.tracer.Start(ctx, "MemoryCache.MGet")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("keys", len(keys)))
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	result := make([]string, len(keys))
+	hits, negativeHits := 0, 0
+	now := time.Now()
+
+	c.mu.Lock()
+	for i, key := range keys {
+		if value, ok := c.get(key, now); ok {
+			result[i] = value
+			hits++
+			if value == domain.NotFoundCacheValue {
+				negativeHits++
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	span.SetAttributes(
+		attribute.Int("hits", hits),
+		attribute.Int("negative_hits", negativeHits),
+	)
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+func (c *MemoryCache) MSet(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	_, span := c.tracer.Start(ctx, "MemoryCache.MSet")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("keys", len(values)),
+		attribute.Int64("ttl", int64(ttl.Seconds())),
+	)
+
+	now := time.Now()
+
+	c.mu.Lock()
+	for key, value := range values {
+		c.set(key, string(value), ttl, now)
+	}
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Delete evicts the keys.
+func (c *MemoryCache) Delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+}
+
+// Len returns the number of cached keys, including the expired ones not
+// evicted yet.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// get must be called with the lock held.
+func (c *MemoryCache) get(key string, now time.Time) (string, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+
+	e := element.Value.(*entry)
+	if e.expired(now) {
+		c.remove(element)
+		return "", false
+	}
+
+	c.lru.MoveToFront(element)
+	return e.value, true
+}
+
+// set must be called with the lock held.
+func (c *MemoryCache) set(key string, value string, ttl time.Duration, now time.Time) {
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	e := &entry{key: key, value: value}
+	if ttl > 0 {
+		e.expiresAt = now.Add(ttl)
+	}
+
+	// A value over the whole budget would evict everything else, and then
+	// itself
+	if c.config.MaxBytes > 0 && e.size() > c.config.MaxBytes {
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(e)
+	c.bytes += e.size()
+
+	for c.overBudget() {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *MemoryCache) overBudget() bool {
+	return (c.config.MaxEntries > 0 && c.lru.Len() > c.config.MaxEntries) ||
+		(c.config.MaxBytes > 0 && c.bytes > c.config.MaxBytes)
+}
+
+func (c *MemoryCache) remove(element *list.Element) {
+	e := c.lru.Remove(element).(*entry)
+	delete(c.entries, e.key)
+	c.bytes -= e.size()
+}
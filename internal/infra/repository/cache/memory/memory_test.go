@@ -0,0 +1,99 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("get", func(t *testing.T) {
+		c := memory.New(otel.Tracer("test"), memory.Config{})
+
+		_, err := c.Get(ctx, "key")
+		assert.ErrorIs(t, err, spotify.ErrCacheMiss)
+
+		require.NoError(t, c.Set(ctx, "key", []byte("value"), time.Minute))
+
+		value, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("mget", func(t *testing.T) {
+		c := memory.New(otel.Tracer("test"), memory.Config{})
+
+		require.NoError(t, c.MSet(ctx, map[string][]byte{"a": []byte("1"), "c": []byte("3")}, time.Minute))
+
+		values, err := c.MGet(ctx, []string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "", "3"}, values)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		c := memory.New(otel.Tracer("test"), memory.Config{})
+
+		require.NoError(t, c.Set(ctx, "key", []byte("value"), time.Millisecond*10))
+		time.Sleep(time.Millisecond * 20)
+
+		_, err := c.Get(ctx, "key")
+		assert.ErrorIs(t, err, spotify.ErrCacheMiss)
+		assert.Zero(t, c.Len())
+	})
+
+	t.Run("max entries", func(t *testing.T) {
+		c := memory.New(otel.Tracer("test"), memory.Config{MaxEntries: 2})
+
+		require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+		require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+		// a is now more recently used than b
+		_, err := c.Get(ctx, "a")
+		require.NoError(t, err)
+		require.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+
+		values, err := c.MGet(ctx, []string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "", "3"}, values)
+	})
+
+	t.Run("max bytes", func(t *testing.T) {
+		c := memory.New(otel.Tracer("test"), memory.Config{MaxBytes: 10})
+
+		require.NoError(t, c.Set(ctx, "a", []byte("1234"), time.Minute))
+		require.NoError(t, c.Set(ctx, "b", []byte("1234"), time.Minute))
+		require.NoError(t, c.Set(ctx, "c", []byte("1234"), time.Minute))
+
+		values, err := c.MGet(ctx, []string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", "1234", "1234"}, values)
+	})
+
+	t.Run("over budget", func(t *testing.T) {
+		c := memory.New(otel.Tracer("test"), memory.Config{MaxBytes: 10})
+
+		require.NoError(t, c.Set(ctx, "a", []byte("1234"), time.Minute))
+		require.NoError(t, c.Set(ctx, "b", []byte("1234567890"), time.Minute))
+
+		values, err := c.MGet(ctx, []string{"a", "b"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1234", ""}, values)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		c := memory.New(otel.Tracer("test"), memory.Config{})
+
+		require.NoError(t, c.Set(ctx, "key", []byte("value"), time.Minute))
+		c.Delete("key", "unknown")
+
+		_, err := c.Get(ctx, "key")
+		assert.ErrorIs(t, err, spotify.ErrCacheMiss)
+	})
+}
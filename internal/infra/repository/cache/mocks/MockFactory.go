@@ -0,0 +1,97 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	cache "github.com/angristan/spotify-search-proxy/internal/infra/repository/cache"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFactory is an autogenerated mock type for the Factory type
+type MockFactory struct {
+	mock.Mock
+}
+
+type MockFactory_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFactory) EXPECT() *MockFactory_Expecter {
+	return &MockFactory_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, config
+func (_m *MockFactory) Execute(ctx context.Context, config cache.Config) (*cache.Backend, error) {
+	ret := _m.Called(ctx, config)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 *cache.Backend
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, cache.Config) (*cache.Backend, error)); ok {
+		return rf(ctx, config)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, cache.Config) *cache.Backend); ok {
+		r0 = rf(ctx, config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cache.Backend)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, cache.Config) error); ok {
+		r1 = rf(ctx, config)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFactory_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockFactory_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - config cache.Config
+func (_e *MockFactory_Expecter) Execute(ctx interface{}, config interface{}) *MockFactory_Execute_Call {
+	return &MockFactory_Execute_Call{Call: _e.mock.On("Execute", ctx, config)}
+}
+
+func (_c *MockFactory_Execute_Call) Run(run func(ctx context.Context, config cache.Config)) *MockFactory_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(cache.Config))
+	})
+	return _c
+}
+
+func (_c *MockFactory_Execute_Call) Return(_a0 *cache.Backend, _a1 error) *MockFactory_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFactory_Execute_Call) RunAndReturn(run func(context.Context, cache.Config) (*cache.Backend, error)) *MockFactory_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFactory creates a new instance of MockFactory. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFactory(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFactory {
+	mock := &MockFactory{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,34 @@
+package noop
+
+import (
+	"context"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+)
+
+// NoopCache doesn't cache anything, so that every request reaches Spotify.
+type NoopCache struct{}
+
+func New() *NoopCache {
+	return &NoopCache{}
+}
+
+func (c *NoopCache) Get(_ context.Context, _ string) (string, error) {
+	return "", spotify.ErrCacheMiss
+}
+
+func (c *NoopCache) Set(_ context.Context, _ string, _ []byte, _ time.Duration) error {
+	return nil
+}
+
+func (c *NoopCache) MGet(_ context.Context, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return make([]string, len(keys)), nil
+}
+
+func (c *NoopCache) MSet(_ context.Context, _ map[string][]byte, _ time.Duration) error {
+	return nil
+}
@@ -0,0 +1,156 @@
+package redis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var ErrUnknownCompression = errors.New("unknown compression algorithm")
+
+// DefaultCompressionMinSize is the size below which values are stored as is.
+const DefaultCompressionMinSize = 512
+
+// Compressed values start with a header byte telling their algorithm. Other
+// values are stored as is, which cached JSON payloads and the not-found
+// sentinel never start with.
+const (
+	headerGzip byte = 0x01
+	headerZstd byte = 0x02
+)
+
+// Compression algorithms.
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// Compression configures how RedisCache compresses the values it stores.
+type Compression struct {
+	// Algorithm is CompressionGzip, CompressionZstd, or CompressionNone (the
+	// default) to store values as is
+	Algorithm string
+	// MinSize is the size below which values are stored as is, as compressing
+	// them isn't worth it. Defaults to DefaultCompressionMinSize.
+	MinSize int
+}
+
+func (c Compression) validate() error {
+	switch c.Algorithm {
+	case "", CompressionNone, CompressionGzip, CompressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownCompression, c.Algorithm)
+	}
+}
+
+// header returns the header byte of the values compressed with the
+// algorithm, or 0 when compression is disabled.
+func (c Compression) header() byte {
+	switch c.Algorithm {
+	case CompressionGzip:
+		return headerGzip
+	case CompressionZstd:
+		return headerZstd
+	default:
+		return 0
+	}
+}
+
+var gzipWriters = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+// The zstd encoder and decoder can be used concurrently, and are only
+// created once needed.
+var (
+	zstdEncoder = sync.OnceValue(func() *zstd.Encoder {
+		// Only fails with invalid options
+		encoder, _ := zstd.NewWriter(nil)
+		return encoder
+	})
+	zstdDecoder = sync.OnceValue(func() *zstd.Decoder {
+		decoder, _ := zstd.NewReader(nil)
+		return decoder
+	})
+)
+
+// compress returns the value to store, compressed unless disabled, too small
+// or not worth it.
+func (c Compression) compress(value []byte) ([]byte, bool) {
+	if len(value) < c.MinSize {
+		return value, false
+	}
+
+	var compressed []byte
+	switch c.Algorithm {
+	case CompressionGzip:
+		var err error
+		if compressed, err = gzipCompress(value); err != nil {
+			return value, false
+		}
+	case CompressionZstd:
+		compressed = zstdEncoder().EncodeAll(value, []byte{headerZstd})
+	default:
+		return value, false
+	}
+
+	if len(compressed) >= len(value) {
+		return value, false
+	}
+	return compressed, true
+}
+
+func gzipCompress(value []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	compressed.WriteByte(headerGzip)
+
+	writer := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(writer)
+	writer.Reset(&compressed)
+
+	if _, err := writer.Write(value); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+// decompress returns the value stored, whether compressed or not, regardless
+// of the configured algorithm.
+func decompress(stored string) (string, bool, error) {
+	if len(stored) == 0 {
+		return stored, false, nil
+	}
+
+	switch stored[0] {
+	case headerGzip:
+		reader, err := gzip.NewReader(bytes.NewReader([]byte(stored[1:])))
+		if err != nil {
+			return "", true, fmt.Errorf("gzip: %w", err)
+		}
+		defer reader.Close()
+
+		value, err := io.ReadAll(reader)
+		if err != nil {
+			return "", true, fmt.Errorf("gzip: %w", err)
+		}
+		return string(value), true, nil
+	case headerZstd:
+		value, err := zstdDecoder().DecodeAll([]byte(stored[1:]), nil)
+		if err != nil {
+			return "", true, fmt.Errorf("zstd: %w", err)
+		}
+		return string(value), true, nil
+	default:
+		return stored, false, nil
+	}
+}
@@ -0,0 +1,106 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InvalidationChannel is the pub/sub channel of the keys written by the
+// instances.
+const InvalidationChannel = "spotify-search-proxy:invalidations"
+
+type invalidation struct {
+	// Origin is the instance that wrote the keys, which doesn't need to
+	// evict them
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+// Invalidator broadcasts the keys written by an instance to the other ones
+// over Redis pub/sub.
+type Invalidator struct {
+	tracer      trace.Tracer
+	redisClient *redis.Client
+	origin      string
+}
+
+func NewInvalidator(
+	tracer trace.Tracer,
+	redisClient *redis.Client,
+) (*Invalidator, error) {
+	origin := make([]byte, 16)
+	if _, err := rand.Read(origin); err != nil {
+		return nil, fmt.Errorf("invalidation origin: %w", err)
+	}
+
+	return &Invalidator{
+		tracer:      tracer,
+		redisClient: redisClient,
+		origin:      hex.EncodeToString(origin),
+	}, nil
+}
+
+func (i *Invalidator) Invalidate(ctx context.Context, keys []string) error {
+	ctx, span := i.tracer.Start(ctx, "Invalidator.Invalidate")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("keys", len(keys)))
+
+	message, err := json.Marshal(invalidation{Origin: i.origin, Keys: keys})
+	if err != nil {
+		return fmt.Errorf("invalidation: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := i.redisClient.Publish(ctx, InvalidationChannel, message).Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("redis publish: %w", err)
+	}
+	return nil
+}
+
+// Invalidations calls evict with the keys written by the other instances
+// until the context is done. Messages published while disconnected are lost,
+// so the evicting cache must expire its values on its own too.
+func (i *Invalidator) Invalidations(ctx context.Context, evict func(keys []string)) error {
+	pubsub := i.redisClient.Subscribe(ctx, InvalidationChannel)
+	defer pubsub.Close()
+
+	// Wait for the subscription, so that failing to connect is reported
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("redis subscribe: %w", err)
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			var invalidation invalidation
+			if err := json.Unmarshal([]byte(message.Payload), &invalidation); err != nil {
+				continue
+			}
+			if invalidation.Origin == i.origin {
+				continue
+			}
+			evict(invalidation.Keys)
+		}
+	}
+}
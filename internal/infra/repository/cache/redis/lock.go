@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// unlockScript only releases a lock still held with the same token, as it
+// may have expired and been acquired by another instance meanwhile.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// TryLock acquires the lock of the key for at most ttl, without waiting.
+func (c *RedisCache) TryLock(ctx context.Context, key string, ttl time.Duration) (func(ctx context.Context) error, bool, error) {
+	ctx, span := c.tracer.Start(ctx, "RedisCache.TryLock")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("key", key),
+		attribute.Int64("ttl", int64(ttl.Seconds())),
+	)
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, false, fmt.Errorf("lock token: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	acquired, err := c.redisClient.SetNX(ctx, key, hex.EncodeToString(token), ttl).Result()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, false, fmt.Errorf("redis setnx %q: %w", key, err)
+	}
+
+	span.SetAttributes(attribute.Bool("acquired", acquired))
+	span.SetStatus(codes.Ok, "")
+	if !acquired {
+		return nil, false, nil
+	}
+
+	unlock := func(ctx context.Context) error {
+		ctx, span := c.tracer.Start(ctx, "RedisCache.Unlock")
+		defer span.End()
+
+		span.SetAttributes(attribute.String("key", key))
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		if err := unlockScript.Run(ctx, c.redisClient, []string{key}, hex.EncodeToString(token)).Err(); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return fmt.Errorf("redis unlock %q: %w", key, err)
+		}
+		return nil
+	}
+
+	return unlock, true, nil
+}
@@ -0,0 +1,146 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// DefaultMigrationBatchSize is the number of keys scanned at once by
+// Recompress.
+const DefaultMigrationBatchSize = 100
+
+// replaceScript only replaces a value that wasn't overwritten meanwhile,
+// keeping its TTL.
+var replaceScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "KEEPTTL")
+	return 1
+end
+return 0
+`)
+
+type RecompressOptions struct {
+	// Pattern matches the keys to rewrite, as for SCAN
+	Pattern string
+	// BatchSize defaults to DefaultMigrationBatchSize
+	BatchSize int
+	// DryRun only counts the keys that would be rewritten
+	DryRun bool
+}
+
+type RecompressStats struct {
+	Scanned int
+	// Rewritten counts the keys compressed, or that would be with DryRun
+	Rewritten int
+	// BytesBefore and BytesAfter are the sizes of the rewritten values
+	BytesBefore int
+	BytesAfter  int
+}
+
+// Recompress rewrites the values stored before compression was enabled, below
+// its threshold, or with another algorithm, with the configured Compression.
+// Values updated meanwhile are left as is.
+func (c *RedisCache) Recompress(ctx context.Context, opts RecompressOptions) (RecompressStats, error) {
+	ctx, span := c.tracer.Start(ctx, "RedisCache.Recompress")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("pattern", opts.Pattern),
+		attribute.Bool("dry_run", opts.DryRun),
+	)
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultMigrationBatchSize
+	}
+
+	var stats RecompressStats
+	var cursor uint64
+	for {
+		keys, next, err := c.redisClient.Scan(ctx, cursor, opts.Pattern, int64(opts.BatchSize)).Result()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return stats, fmt.Errorf("redis scan: %w", err)
+		}
+
+		if err := c.recompressBatch(ctx, keys, opts.DryRun, &stats); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return stats, err
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("scanned", stats.Scanned),
+		attribute.Int("rewritten", stats.Rewritten),
+	)
+	return stats, nil
+}
+
+func (c *RedisCache) recompressBatch(ctx context.Context, keys []string, dryRun bool, stats *RecompressStats) error {
+	stats.Scanned += len(keys)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values, err := c.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return fmt.Errorf("redis mget: %w", err)
+	}
+
+	replacements := map[string][2]string{}
+	for i, value := range values {
+		// Keys may have expired since the scan, or not be strings
+		stored, ok := value.(string)
+		if !ok || len(stored) == 0 || stored[0] == c.config.Compression.header() {
+			continue
+		}
+
+		// Values compressed with another algorithm are compressed again
+		value, _, err := decompress(stored)
+		if err != nil {
+			continue
+		}
+
+		compressed, ok := c.config.Compression.compress([]byte(value))
+		if !ok {
+			continue
+		}
+
+		replacements[keys[i]] = [2]string{stored, string(compressed)}
+		stats.BytesBefore += len(stored)
+		stats.BytesAfter += len(compressed)
+	}
+
+	if dryRun || len(replacements) == 0 {
+		stats.Rewritten += len(replacements)
+		return nil
+	}
+
+	results := make([]*redis.Cmd, 0, len(replacements))
+	_, err = c.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, replacement := range replacements {
+			results = append(results, replaceScript.Eval(ctx, pipe, []string{key}, replacement[0], replacement[1]))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis recompress: %w", err)
+	}
+
+	for _, result := range results {
+		if replaced, _ := result.Int(); replaced == 1 {
+			stats.Rewritten++
+		}
+	}
+	return nil
+}
@@ -6,27 +6,41 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
 	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
-var ErrCacheMiss = errors.New("cache: key not found")
+type Config struct {
+	Compression Compression
+}
 
 type RedisCache struct {
 	tracer      trace.Tracer
 	redisClient *redis.Client
+	config      Config
 }
 
 func New(
 	tracer trace.Tracer,
 	redisClient *redis.Client,
-) *RedisCache {
+	config Config,
+) (*RedisCache, error) {
+	if err := config.Compression.validate(); err != nil {
+		return nil, err
+	}
+	if config.Compression.MinSize <= 0 {
+		config.Compression.MinSize = DefaultCompressionMinSize
+	}
+
 	return &RedisCache{
 		tracer:      tracer,
 		redisClient: redisClient,
-	}
+		config:      config,
+	}, nil
 }
 
 func (c *RedisCache) Get(ctx context.Context, key string) (string, error) {
@@ -38,11 +52,11 @@ func (c *RedisCache) Get(ctx context.Context, key string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	value, err := c.redisClient.Get(ctx, key).Result()
+	stored, err := c.redisClient.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			span.SetStatus(codes.Ok, "Cache miss")
-			return "", ErrCacheMiss
+			return "", spotify.ErrCacheMiss
 		}
 
 		span.RecordError(err)
@@ -50,7 +64,20 @@ func (c *RedisCache) Get(ctx context.Context, key string) (string, error) {
 		return "", fmt.Errorf("redis get %q: %w", key, err)
 	}
 
-	span.SetAttributes(attribute.Int("value_length", len(value)))
+	value, compressed, err := decompress(stored)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("redis get %q: %w", key, err)
+	}
+
+	// Not-found outcomes are cached too, and must be told apart from the
+	// payloads when looking at hit rates
+	span.SetAttributes(
+		attribute.Int("value_length", len(stored)),
+		attribute.Bool("compressed", compressed),
+		attribute.Bool("negative", value == domain.NotFoundCacheValue),
+	)
 	span.SetStatus(codes.Ok, "Cache hit")
 	return value, nil
 }
@@ -64,9 +91,109 @@ func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time
 
 	span.SetAttributes(attribute.Int64("ttl", int64(ttl.Seconds())))
 
-	err := c.redisClient.Set(ctx, key, value, ttl).Err()
+	stored, compressed := c.config.Compression.compress(value)
+	span.SetAttributes(attribute.Bool("compressed", compressed))
+	if compressed {
+		span.SetAttributes(attribute.Float64("compression_ratio", float64(len(stored))/float64(len(value))))
+	}
+
+	err := c.redisClient.Set(ctx, key, stored, ttl).Err()
 	if err != nil {
 		return fmt.Errorf("redis set %q: %w", key, err)
 	}
 	return nil
 }
+
+func (c *RedisCache) MGet(ctx context.Context, keys []string) ([]string, error) {
+	ctx, span := c.tracer.Start(ctx, "RedisCache.MGet")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("keys", len(keys)))
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	values, err := c.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("redis mget: %w", err)
+	}
+
+	result := make([]string, len(keys))
+	hits, negativeHits := 0, 0
+	for i, stored := range values {
+		// Missing keys are nil
+		stored, ok := stored.(string)
+		if !ok {
+			continue
+		}
+
+		value, _, err := decompress(stored)
+		if err != nil {
+			// Corrupted values are missing, and get overwritten
+			span.RecordError(fmt.Errorf("redis mget %q: %w", keys[i], err))
+			continue
+		}
+
+		result[i] = value
+		hits++
+		if value == domain.NotFoundCacheValue {
+			negativeHits++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("hits", hits),
+		attribute.Int("negative_hits", negativeHits),
+	)
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// MSet sets all the values in a single round trip. Redis' MSET doesn't
+// support expiration, so this uses a pipeline of SET commands instead.
+func (c *RedisCache) MSet(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	ctx, span := c.tracer.Start(ctx, "RedisCache.MSet")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("keys", len(values)),
+		attribute.Int64("ttl", int64(ttl.Seconds())),
+	)
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	size, storedSize, compressedKeys := 0, 0, 0
+	_, err := c.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range values {
+			stored, compressed := c.config.Compression.compress(value)
+			if compressed {
+				size += len(value)
+				storedSize += len(stored)
+				compressedKeys++
+			}
+			pipe.Set(ctx, key, stored, ttl)
+		}
+		return nil
+	})
+
+	span.SetAttributes(attribute.Int("compressed_keys", compressedKeys))
+	if compressedKeys > 0 {
+		span.SetAttributes(attribute.Float64("compression_ratio", float64(storedSize)/float64(size)))
+	}
+
+	if err != nil {
+		return fmt.Errorf("redis mset: %w", err)
+	}
+	return nil
+}
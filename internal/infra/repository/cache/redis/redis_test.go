@@ -0,0 +1,251 @@
+package redis_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/redis"
+	goRedis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func newCache(t *testing.T, server *miniredis.Miniredis, compression redis.Compression) *redis.RedisCache {
+	redisClient := goRedis.NewClient(&goRedis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = redisClient.Close() })
+
+	c, err := redis.New(otel.Tracer("test"), redisClient, redis.Config{Compression: compression})
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestRedisCache_Compression(t *testing.T) {
+	ctx := context.Background()
+	large := `{"items": [` + strings.Repeat(`{"type": "album", "available_markets": ["AD", "AE", "AG"]},`, 50) + `{}]}`
+	small := `{"items": []}`
+
+	t.Run("compressed", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		c := newCache(t, server, redis.Compression{Algorithm: redis.CompressionGzip})
+
+		require.NoError(t, c.Set(ctx, "large", []byte(large), time.Hour))
+		require.NoError(t, c.MSet(ctx, map[string][]byte{"small": []byte(small), "large-batch": []byte(large)}, time.Hour))
+
+		for _, key := range []string{"large", "large-batch"} {
+			stored, err := server.Get(key)
+			require.NoError(t, err)
+			assert.Equal(t, byte(0x01), stored[0])
+			assert.Less(t, len(stored), len(large))
+		}
+
+		stored, err := server.Get("small")
+		require.NoError(t, err)
+		assert.Equal(t, small, stored)
+
+		value, err := c.Get(ctx, "large")
+		require.NoError(t, err)
+		assert.Equal(t, large, value)
+
+		values, err := c.MGet(ctx, []string{"large", "small", "large-batch", "missing"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{large, small, large, ""}, values)
+	})
+
+	t.Run("uncompressed values", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		c := newCache(t, server, redis.Compression{Algorithm: redis.CompressionGzip})
+
+		require.NoError(t, server.Set("legacy", large))
+
+		value, err := c.Get(ctx, "legacy")
+		require.NoError(t, err)
+		assert.Equal(t, large, value)
+
+		values, err := c.MGet(ctx, []string{"legacy"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{large}, values)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		c := newCache(t, server, redis.Compression{})
+		compressing := newCache(t, server, redis.Compression{Algorithm: redis.CompressionGzip})
+
+		require.NoError(t, compressing.Set(ctx, "compressed", []byte(large), time.Hour))
+		require.NoError(t, c.Set(ctx, "large", []byte(large), time.Hour))
+
+		stored, err := server.Get("large")
+		require.NoError(t, err)
+		assert.Equal(t, large, stored)
+
+		// Values compressed before compression was disabled still decode
+		value, err := c.Get(ctx, "compressed")
+		require.NoError(t, err)
+		assert.Equal(t, large, value)
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		c := newCache(t, server, redis.Compression{Algorithm: redis.CompressionGzip})
+
+		require.NoError(t, server.Set("corrupted", "\x01not gzip"))
+
+		_, err := c.Get(ctx, "corrupted")
+		assert.Error(t, err)
+
+		values, err := c.MGet(ctx, []string{"corrupted"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{""}, values)
+	})
+
+	t.Run("unknown algorithm", func(t *testing.T) {
+		_, err := redis.New(otel.Tracer("test"), nil, redis.Config{Compression: redis.Compression{Algorithm: "lz4"}})
+		assert.ErrorIs(t, err, redis.ErrUnknownCompression)
+	})
+}
+
+func TestRedisCache_CompressionAlgorithms(t *testing.T) {
+	ctx := context.Background()
+	large := `{"items": [` + strings.Repeat(`{"type": "album", "available_markets": ["AD", "AE", "AG"]},`, 50) + `{}]}`
+
+	algorithms := []struct {
+		algorithm string
+		header    byte
+	}{
+		{algorithm: redis.CompressionGzip, header: 0x01},
+		{algorithm: redis.CompressionZstd, header: 0x02},
+	}
+
+	server := miniredis.RunT(t)
+	for _, written := range algorithms {
+		c := newCache(t, server, redis.Compression{Algorithm: written.algorithm})
+		require.NoError(t, c.Set(ctx, written.algorithm, []byte(large), time.Hour))
+
+		stored, err := server.Get(written.algorithm)
+		require.NoError(t, err)
+		assert.Equal(t, written.header, stored[0])
+		assert.Less(t, len(stored), len(large))
+	}
+
+	// Values are read whichever algorithm wrote them
+	for _, algorithm := range []string{redis.CompressionNone, redis.CompressionGzip, redis.CompressionZstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			c := newCache(t, server, redis.Compression{Algorithm: algorithm})
+
+			for _, written := range algorithms {
+				value, err := c.Get(ctx, written.algorithm)
+				require.NoError(t, err)
+				assert.Equal(t, large, value)
+			}
+
+			values, err := c.MGet(ctx, []string{redis.CompressionGzip, redis.CompressionZstd})
+			require.NoError(t, err)
+			assert.Equal(t, []string{large, large}, values)
+		})
+	}
+
+	t.Run("corrupted", func(t *testing.T) {
+		c := newCache(t, server, redis.Compression{Algorithm: redis.CompressionZstd})
+
+		require.NoError(t, server.Set("corrupted", "\x02not zstd"))
+
+		_, err := c.Get(ctx, "corrupted")
+		assert.Error(t, err)
+	})
+}
+
+func TestRedisCache_Recompress(t *testing.T) {
+	ctx := context.Background()
+	large := `{"items": [` + strings.Repeat(`{"type": "album", "available_markets": ["AD", "AE", "AG"]},`, 50) + `{}]}`
+	small := `{"items": []}`
+
+	setup := func(t *testing.T) (*redis.RedisCache, *miniredis.Miniredis) {
+		server := miniredis.RunT(t)
+		c := newCache(t, server, redis.Compression{Algorithm: redis.CompressionGzip})
+
+		require.NoError(t, server.Set("spotify:large", large))
+		server.SetTTL("spotify:large", time.Hour)
+		require.NoError(t, server.Set("spotify:small", small))
+		require.NoError(t, server.Set("lock:spotify:large", large))
+		require.NoError(t, c.Set(ctx, "spotify:compressed", []byte(large), time.Hour))
+
+		return c, server
+	}
+
+	t.Run("rewrite", func(t *testing.T) {
+		c, server := setup(t)
+
+		stats, err := c.Recompress(ctx, redis.RecompressOptions{Pattern: "spotify:*", BatchSize: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 3, stats.Scanned)
+		assert.Equal(t, 1, stats.Rewritten)
+		assert.Equal(t, len(large), stats.BytesBefore)
+		assert.Less(t, stats.BytesAfter, stats.BytesBefore)
+
+		stored, err := server.Get("spotify:large")
+		require.NoError(t, err)
+		assert.Equal(t, byte(0x01), stored[0])
+		assert.Equal(t, time.Hour, server.TTL("spotify:large"))
+
+		value, err := c.Get(ctx, "spotify:large")
+		require.NoError(t, err)
+		assert.Equal(t, large, value)
+
+		stored, err = server.Get("lock:spotify:large")
+		require.NoError(t, err)
+		assert.Equal(t, large, stored)
+	})
+
+	t.Run("other algorithm", func(t *testing.T) {
+		c, server := setup(t)
+		zstd := newCache(t, server, redis.Compression{Algorithm: redis.CompressionZstd})
+
+		stats, err := zstd.Recompress(ctx, redis.RecompressOptions{Pattern: "spotify:*"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.Rewritten)
+
+		for _, key := range []string{"spotify:large", "spotify:compressed"} {
+			stored, err := server.Get(key)
+			require.NoError(t, err)
+			assert.Equal(t, byte(0x02), stored[0])
+
+			value, err := c.Get(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, large, value)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		c, server := setup(t)
+
+		stats, err := c.Recompress(ctx, redis.RecompressOptions{Pattern: "spotify:*", DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.Rewritten)
+
+		stored, err := server.Get("spotify:large")
+		require.NoError(t, err)
+		assert.Equal(t, large, stored)
+	})
+}
+
+func TestRedisCache_DeleteMatching(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	c := newCache(t, server, redis.Compression{})
+
+	for _, key := range []string{"spotify:v2:g0:a", "spotify:v2:g0:b", "spotify:v2:g1:a", "lock:spotify:v2:g0:a"} {
+		require.NoError(t, server.Set(key, "value"))
+	}
+
+	deleted, err := c.DeleteMatching(ctx, "spotify:*", func(key string) bool {
+		return strings.HasPrefix(key, "spotify:v2:g0:")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.ElementsMatch(t, []string{"spotify:v2:g1:a", "lock:spotify:v2:g0:a"}, server.Keys())
+}
@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// sweepBatchSize is the number of keys scanned at once by DeleteMatching.
+const sweepBatchSize = 500
+
+// DeleteMatching deletes the keys matching the pattern, as for SCAN, for
+// which match returns true. Keys are unlinked a batch at a time, so that
+// Redis keeps serving other clients meanwhile.
+func (c *RedisCache) DeleteMatching(ctx context.Context, pattern string, match func(key string) bool) (int, error) {
+	ctx, span := c.tracer.Start(ctx, "RedisCache.DeleteMatching")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("pattern", pattern))
+
+	scanned, deleted := 0, 0
+	var cursor uint64
+	for {
+		keys, next, err := c.redisClient.Scan(ctx, cursor, pattern, sweepBatchSize).Result()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return deleted, fmt.Errorf("redis scan: %w", err)
+		}
+		scanned += len(keys)
+
+		var matching []string
+		for _, key := range keys {
+			if match(key) {
+				matching = append(matching, key)
+			}
+		}
+
+		if len(matching) > 0 {
+			unlinked, err := c.redisClient.Unlink(ctx, matching...).Result()
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return deleted, fmt.Errorf("redis unlink: %w", err)
+			}
+			deleted += int(unlinked)
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("scanned", scanned),
+		attribute.Int("deleted", deleted),
+	)
+	return deleted, nil
+}
@@ -0,0 +1,23 @@
+package tiered
+
+import (
+	"context"
+	"time"
+)
+
+// Cache is the shared tier behind the in-process one.
+type Cache interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	MGet(ctx context.Context, keys []string) ([]string, error)
+	MSet(ctx context.Context, values map[string][]byte, ttl time.Duration) error
+}
+
+// Invalidator broadcasts the keys written by an instance to the other ones,
+// so that they evict their in-process copies.
+type Invalidator interface {
+	Invalidate(ctx context.Context, keys []string) error
+	// Invalidations calls evict with the keys written by the other instances
+	// until the context is done.
+	Invalidations(ctx context.Context, evict func(keys []string)) error
+}
@@ -0,0 +1,251 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockCache is an autogenerated mock type for the Cache type
+type MockCache struct {
+	mock.Mock
+}
+
+type MockCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCache) EXPECT() *MockCache_Expecter {
+	return &MockCache_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *MockCache) Get(ctx context.Context, key string) (string, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCache_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockCache_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockCache_Expecter) Get(ctx interface{}, key interface{}) *MockCache_Get_Call {
+	return &MockCache_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockCache_Get_Call) Run(run func(ctx context.Context, key string)) *MockCache_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCache_Get_Call) Return(_a0 string, _a1 error) *MockCache_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCache_Get_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockCache_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MGet provides a mock function with given fields: ctx, keys
+func (_m *MockCache) MGet(ctx context.Context, keys []string) ([]string, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MGet")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCache_MGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MGet'
+type MockCache_MGet_Call struct {
+	*mock.Call
+}
+
+// MGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockCache_Expecter) MGet(ctx interface{}, keys interface{}) *MockCache_MGet_Call {
+	return &MockCache_MGet_Call{Call: _e.mock.On("MGet", ctx, keys)}
+}
+
+func (_c *MockCache_MGet_Call) Run(run func(ctx context.Context, keys []string)) *MockCache_MGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockCache_MGet_Call) Return(_a0 []string, _a1 error) *MockCache_MGet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCache_MGet_Call) RunAndReturn(run func(context.Context, []string) ([]string, error)) *MockCache_MGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MSet provides a mock function with given fields: ctx, values, ttl
+func (_m *MockCache) MSet(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	ret := _m.Called(ctx, values, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MSet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string][]byte, time.Duration) error); ok {
+		r0 = rf(ctx, values, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCache_MSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MSet'
+type MockCache_MSet_Call struct {
+	*mock.Call
+}
+
+// MSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - values map[string][]byte
+//   - ttl time.Duration
+func (_e *MockCache_Expecter) MSet(ctx interface{}, values interface{}, ttl interface{}) *MockCache_MSet_Call {
+	return &MockCache_MSet_Call{Call: _e.mock.On("MSet", ctx, values, ttl)}
+}
+
+func (_c *MockCache_MSet_Call) Run(run func(ctx context.Context, values map[string][]byte, ttl time.Duration)) *MockCache_MSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[string][]byte), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockCache_MSet_Call) Return(_a0 error) *MockCache_MSet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_MSet_Call) RunAndReturn(run func(context.Context, map[string][]byte, time.Duration) error) *MockCache_MSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCache_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockCache_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value []byte
+//   - ttl time.Duration
+func (_e *MockCache_Expecter) Set(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockCache_Set_Call {
+	return &MockCache_Set_Call{Call: _e.mock.On("Set", ctx, key, value, ttl)}
+}
+
+func (_c *MockCache_Set_Call) Run(run func(ctx context.Context, key string, value []byte, ttl time.Duration)) *MockCache_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockCache_Set_Call) Return(_a0 error) *MockCache_Set_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_Set_Call) RunAndReturn(run func(context.Context, string, []byte, time.Duration) error) *MockCache_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCache creates a new instance of MockCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCache {
+	mock := &MockCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
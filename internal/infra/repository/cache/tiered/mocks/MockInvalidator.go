@@ -0,0 +1,130 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockInvalidator is an autogenerated mock type for the Invalidator type
+type MockInvalidator struct {
+	mock.Mock
+}
+
+type MockInvalidator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInvalidator) EXPECT() *MockInvalidator_Expecter {
+	return &MockInvalidator_Expecter{mock: &_m.Mock}
+}
+
+// Invalidate provides a mock function with given fields: ctx, keys
+func (_m *MockInvalidator) Invalidate(ctx context.Context, keys []string) error {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invalidate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInvalidator_Invalidate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invalidate'
+type MockInvalidator_Invalidate_Call struct {
+	*mock.Call
+}
+
+// Invalidate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockInvalidator_Expecter) Invalidate(ctx interface{}, keys interface{}) *MockInvalidator_Invalidate_Call {
+	return &MockInvalidator_Invalidate_Call{Call: _e.mock.On("Invalidate", ctx, keys)}
+}
+
+func (_c *MockInvalidator_Invalidate_Call) Run(run func(ctx context.Context, keys []string)) *MockInvalidator_Invalidate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockInvalidator_Invalidate_Call) Return(_a0 error) *MockInvalidator_Invalidate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInvalidator_Invalidate_Call) RunAndReturn(run func(context.Context, []string) error) *MockInvalidator_Invalidate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Invalidations provides a mock function with given fields: ctx, evict
+func (_m *MockInvalidator) Invalidations(ctx context.Context, evict func([]string)) error {
+	ret := _m.Called(ctx, evict)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invalidations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func([]string)) error); ok {
+		r0 = rf(ctx, evict)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInvalidator_Invalidations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invalidations'
+type MockInvalidator_Invalidations_Call struct {
+	*mock.Call
+}
+
+// Invalidations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - evict func([]string)
+func (_e *MockInvalidator_Expecter) Invalidations(ctx interface{}, evict interface{}) *MockInvalidator_Invalidations_Call {
+	return &MockInvalidator_Invalidations_Call{Call: _e.mock.On("Invalidations", ctx, evict)}
+}
+
+func (_c *MockInvalidator_Invalidations_Call) Run(run func(ctx context.Context, evict func([]string))) *MockInvalidator_Invalidations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func([]string)))
+	})
+	return _c
+}
+
+func (_c *MockInvalidator_Invalidations_Call) Return(_a0 error) *MockInvalidator_Invalidations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInvalidator_Invalidations_Call) RunAndReturn(run func(context.Context, func([]string)) error) *MockInvalidator_Invalidations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInvalidator creates a new instance of MockInvalidator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInvalidator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInvalidator {
+	mock := &MockInvalidator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,237 @@
+package tiered
+
+import (
+	"context"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/memory"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const DefaultTTL = time.Minute
+
+// Tiers of the cache, as reported by the span attributes and metrics.
+const (
+	tierL1 = "l1"
+	tierL2 = "l2"
+)
+
+type Config struct {
+	// TTL bounds how long values are kept in the in-process tier, as other
+	// instances may update them meanwhile. Defaults to DefaultTTL.
+	TTL time.Duration
+	// Invalidator, when set, evicts the keys written by other instances from
+	// the in-process tier.
+	Invalidator Invalidator
+	// Meter records the hits and misses of each tier, the global one's when
+	// nil.
+	Meter metric.Meter
+}
+
+// TieredCache is an in-process cache in front of a shared one, which it
+// reads and writes through.
+type TieredCache struct {
+	tracer trace.Tracer
+	l1     *memory.MemoryCache
+	l2     Cache
+	config Config
+
+	hits   metric.Int64Counter
+	misses metric.Int64Counter
+}
+
+func New(
+	tracer trace.Tracer,
+	l1 *memory.MemoryCache,
+	l2 Cache,
+	config Config,
+) *TieredCache {
+	if config.TTL <= 0 {
+		config.TTL = DefaultTTL
+	}
+	if config.Meter == nil {
+		config.Meter = otel.Meter("spotify-search-proxy")
+	}
+
+	hits, err := config.Meter.Int64Counter(
+		"cache.hits",
+		metric.WithDescription("Cache lookups found in a tier"),
+		metric.WithUnit("{key}"),
+	)
+	if err != nil {
+		hits = noop.Int64Counter{}
+	}
+	misses, err := config.Meter.Int64Counter(
+		"cache.misses",
+		metric.WithDescription("Cache lookups missing from a tier"),
+		metric.WithUnit("{key}"),
+	)
+	if err != nil {
+		misses = noop.Int64Counter{}
+	}
+
+	return &TieredCache{
+		tracer: tracer,
+		l1:     l1,
+		l2:     l2,
+		config: config,
+		hits:   hits,
+		misses: misses,
+	}
+}
+
+func (c *TieredCache) Get(ctx context.Context, key string) (string, error) {
+	ctx, span := c.tracer.Start(ctx, "TieredCache.Get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("key", key))
+
+	if value, err := c.l1.Get(ctx, key); err == nil {
+		c.record(ctx, tierL1, 1, 0)
+		span.SetAttributes(attribute.String("tier", tierL1))
+		return value, nil
+	}
+	c.record(ctx, tierL1, 0, 1)
+
+	value, err := c.l2.Get(ctx, key)
+	if err != nil {
+		c.record(ctx, tierL2, 0, 1)
+		return "", err
+	}
+	c.record(ctx, tierL2, 1, 0)
+	span.SetAttributes(attribute.String("tier", tierL2))
+
+	_ = c.l1.Set(ctx, key, []byte(value), c.config.TTL)
+	return value, nil
+}
+
+// Set writes the value through to the shared tier, and invalidates the
+// in-process copies of the other instances.
+func (c *TieredCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	ctx, span := c.tracer.Start(ctx, "TieredCache.Set")
+	defer span.End()
+
+	if err := c.l2.Set(ctx, key, value, ttl); err != nil {
+		c.l1.Delete(key)
+		return err
+	}
+
+	_ = c.l1.Set(ctx, key, value, c.l1TTL(ttl))
+	c.invalidate(ctx, []string{key})
+	return nil
+}
+
+func (c *TieredCache) MGet(ctx context.Context, keys []string) ([]string, error) {
+	ctx, span := c.tracer.Start(ctx, "TieredCache.MGet")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("keys", len(keys)))
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	values, err := c.l1.MGet(ctx, keys)
+	if err != nil {
+		values = make([]string, len(keys))
+	}
+
+	var missing []string
+	var missingIndexes []int
+	for i, value := range values {
+		if value == "" {
+			missing = append(missing, keys[i])
+			missingIndexes = append(missingIndexes, i)
+		}
+	}
+	c.record(ctx, tierL1, int64(len(keys)-len(missing)), int64(len(missing)))
+	span.SetAttributes(attribute.Int("l1_hits", len(keys)-len(missing)))
+
+	if len(missing) == 0 {
+		return values, nil
+	}
+
+	l2Values, err := c.l2.MGet(ctx, missing)
+	if err != nil {
+		c.record(ctx, tierL2, 0, int64(len(missing)))
+		return nil, err
+	}
+
+	found := map[string][]byte{}
+	for i, value := range l2Values {
+		if value != "" {
+			values[missingIndexes[i]] = value
+			found[missing[i]] = []byte(value)
+		}
+	}
+	c.record(ctx, tierL2, int64(len(found)), int64(len(missing)-len(found)))
+	span.SetAttributes(attribute.Int("l2_hits", len(found)))
+
+	_ = c.l1.MSet(ctx, found, c.config.TTL)
+	return values, nil
+}
+
+// MSet writes the values through to the shared tier, and invalidates the
+// in-process copies of the other instances.
+func (c *TieredCache) MSet(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	ctx, span := c.tracer.Start(ctx, "TieredCache.MSet")
+	defer span.End()
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	if err := c.l2.MSet(ctx, values, ttl); err != nil {
+		c.l1.Delete(keys...)
+		return err
+	}
+
+	_ = c.l1.MSet(ctx, values, c.l1TTL(ttl))
+	c.invalidate(ctx, keys)
+	return nil
+}
+
+// Listen evicts the keys written by the other instances from the in-process
+// tier until the context is done. It is a no-op without a Config.Invalidator.
+func (c *TieredCache) Listen(ctx context.Context) error {
+	if c.config.Invalidator == nil {
+		return nil
+	}
+
+	return c.config.Invalidator.Invalidations(ctx, func(keys []string) {
+		c.l1.Delete(keys...)
+	})
+}
+
+func (c *TieredCache) invalidate(ctx context.Context, keys []string) {
+	if c.config.Invalidator == nil || len(keys) == 0 {
+		return
+	}
+
+	// The other instances evict their copies after Config.TTL regardless
+	if err := c.config.Invalidator.Invalidate(ctx, keys); err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+}
+
+func (c *TieredCache) l1TTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return c.config.TTL
+	}
+	return min(ttl, c.config.TTL)
+}
+
+func (c *TieredCache) record(ctx context.Context, tier string, hits int64, misses int64) {
+	attributes := metric.WithAttributes(attribute.String("tier", tier))
+	if hits > 0 {
+		c.hits.Add(ctx, hits, attributes)
+	}
+	if misses > 0 {
+		c.misses.Add(ctx, misses, attributes)
+	}
+}
@@ -0,0 +1,180 @@
+package tiered_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/memory"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/tiered"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/tiered/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var errCacheMiss = errors.New("cache: key not found")
+
+// lookups returns the number of hits and misses recorded by the reader, per
+// tier.
+func lookups(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	var metrics metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &metrics))
+
+	counts := map[string]int64{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				tier, _ := point.Attributes.Value(attribute.Key("tier"))
+				counts[tier.AsString()+" "+m.Name] += point.Value
+			}
+		}
+	}
+	return counts
+}
+
+func TestTieredCache(t *testing.T) {
+	ctx := context.Background()
+
+	newCache := func(t *testing.T, invalidator tiered.Invalidator) (*tiered.TieredCache, *memory.MemoryCache, *mocks.MockCache, sdkmetric.Reader) {
+		mockedCache := &mocks.MockCache{}
+		t.Cleanup(func() { mockedCache.AssertExpectations(t) })
+
+		reader := sdkmetric.NewManualReader()
+		l1 := memory.New(otel.Tracer("test"), memory.Config{})
+
+		c := tiered.New(otel.Tracer("test"), l1, mockedCache, tiered.Config{
+			TTL:         time.Minute,
+			Invalidator: invalidator,
+			Meter:       sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"),
+		})
+		return c, l1, mockedCache, reader
+	}
+
+	t.Run("read through", func(t *testing.T) {
+		c, _, mockedCache, reader := newCache(t, nil)
+
+		mockedCache.On("Get", mock.Anything, "key").
+			Return("value", nil).
+			Once()
+
+		for i := 0; i < 2; i++ {
+			value, err := c.Get(ctx, "key")
+			require.NoError(t, err)
+			assert.Equal(t, "value", value)
+		}
+
+		assert.Equal(t, map[string]int64{
+			"l1 cache.hits":   1,
+			"l1 cache.misses": 1,
+			"l2 cache.hits":   1,
+		}, lookups(t, reader))
+	})
+
+	t.Run("miss", func(t *testing.T) {
+		c, _, mockedCache, reader := newCache(t, nil)
+
+		mockedCache.On("Get", mock.Anything, "key").
+			Return("", errCacheMiss).
+			Once()
+
+		_, err := c.Get(ctx, "key")
+		assert.ErrorIs(t, err, errCacheMiss)
+
+		assert.Equal(t, map[string]int64{
+			"l1 cache.misses": 1,
+			"l2 cache.misses": 1,
+		}, lookups(t, reader))
+	})
+
+	t.Run("mget", func(t *testing.T) {
+		c, l1, mockedCache, reader := newCache(t, nil)
+
+		require.NoError(t, l1.Set(ctx, "a", []byte("1"), time.Minute))
+		mockedCache.On("MGet", mock.Anything, []string{"b", "c"}).
+			Return([]string{"2", ""}, nil).
+			Once()
+
+		values, err := c.MGet(ctx, []string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", ""}, values)
+
+		value, err := l1.Get(ctx, "b")
+		require.NoError(t, err)
+		assert.Equal(t, "2", value)
+
+		assert.Equal(t, map[string]int64{
+			"l1 cache.hits":   1,
+			"l1 cache.misses": 2,
+			"l2 cache.hits":   1,
+			"l2 cache.misses": 1,
+		}, lookups(t, reader))
+	})
+
+	t.Run("write through", func(t *testing.T) {
+		mockedInvalidator := &mocks.MockInvalidator{}
+		t.Cleanup(func() { mockedInvalidator.AssertExpectations(t) })
+
+		c, l1, mockedCache, _ := newCache(t, mockedInvalidator)
+
+		mockedCache.On("Set", mock.Anything, "key", []byte("value"), time.Hour).
+			Return(nil).
+			Once()
+		mockedInvalidator.On("Invalidate", mock.Anything, []string{"key"}).
+			Return(nil).
+			Once()
+		mockedCache.On("MSet", mock.Anything, map[string][]byte{"other": []byte("value")}, time.Hour).
+			Return(nil).
+			Once()
+		mockedInvalidator.On("Invalidate", mock.Anything, []string{"other"}).
+			Return(nil).
+			Once()
+
+		require.NoError(t, c.Set(ctx, "key", []byte("value"), time.Hour))
+		require.NoError(t, c.MSet(ctx, map[string][]byte{"other": []byte("value")}, time.Hour))
+
+		values, err := l1.MGet(ctx, []string{"key", "other"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"value", "value"}, values)
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		c, l1, mockedCache, _ := newCache(t, nil)
+
+		require.NoError(t, l1.Set(ctx, "key", []byte("old"), time.Minute))
+		mockedCache.On("Set", mock.Anything, "key", []byte("value"), time.Hour).
+			Return(errors.New("connection refused")).
+			Once()
+
+		assert.Error(t, c.Set(ctx, "key", []byte("value"), time.Hour))
+
+		_, err := l1.Get(ctx, "key")
+		assert.ErrorIs(t, err, spotify.ErrCacheMiss)
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+		mockedInvalidator := &mocks.MockInvalidator{}
+		t.Cleanup(func() { mockedInvalidator.AssertExpectations(t) })
+
+		c, l1, _, _ := newCache(t, mockedInvalidator)
+
+		require.NoError(t, l1.Set(ctx, "key", []byte("value"), time.Minute))
+		mockedInvalidator.On("Invalidations", mock.Anything, mock.Anything).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(func(keys []string))([]string{"key"})
+			}).
+			Once()
+
+		require.NoError(t, c.Listen(ctx))
+
+		_, err := l1.Get(ctx, "key")
+		assert.ErrorIs(t, err, spotify.ErrCacheMiss)
+	})
+}
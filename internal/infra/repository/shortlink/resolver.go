@@ -0,0 +1,70 @@
+package shortlink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Resolver follows short links such as https://spotify.link/... by reading
+// the redirect of their first response, without following it. Each call
+// makes a request to the short link's host.
+type Resolver struct {
+	tracer     trace.Tracer
+	httpClient *http.Client
+}
+
+func New(
+	tracer trace.Tracer,
+	httpClient *http.Client,
+	timeout time.Duration,
+) *Resolver {
+	return &Resolver{
+		tracer: tracer,
+		httpClient: &http.Client{
+			Transport: httpClient.Transport,
+			Timeout:   timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (r *Resolver) Resolve(ctx context.Context, link string) (string, error) {
+	ctx, span := r.tracer.Start(ctx, "Resolver.Resolve")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("link", link))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", appspotify.ErrInvalidLink, err.Error())
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("resolve %q: %w", link, err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("status", resp.StatusCode))
+
+	switch {
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		location, err := resp.Location()
+		if err != nil {
+			return "", fmt.Errorf("resolve %q: %w", link, err)
+		}
+		return location.String(), nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return "", fmt.Errorf("%w: unknown short link", appspotify.ErrInvalidLink)
+	}
+
+	return "", fmt.Errorf("resolve %q: unexpected status %d", link, resp.StatusCode)
+}
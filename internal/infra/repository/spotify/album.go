@@ -0,0 +1,65 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	spotifyLib "github.com/zmb3/spotify/v2"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Spotify returns at most 50 tracks of an album per request.
+const albumTracksPageSize = 50
+
+// GetAlbumTracks returns the tracks of an album, going through as many pages
+// as needed to return up to maxTracks of them.
+func (client *SpotifyClient) GetAlbumTracks(ctx context.Context, id string, market string, maxTracks int) (*appspotify.Tracklist, error) {
+	ctx, span := client.tracer.Start(ctx, "SpotifyClient.GetAlbumTracks")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("id", id),
+		attribute.String("market", market),
+		attribute.Int("max_tracks", maxTracks),
+	)
+
+	err := client.RenewTokenIfNeeded(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client.RenewTokenIfNeeded: %w", err)
+	}
+
+	tracklist := &appspotify.Tracklist{Tracks: []domain.Item{}}
+	pages := 0
+	for len(tracklist.Tracks) < maxTracks {
+		requestOptions := []spotifyLib.RequestOption{
+			spotifyLib.Limit(min(albumTracksPageSize, maxTracks-len(tracklist.Tracks))),
+			spotifyLib.Offset(len(tracklist.Tracks)),
+		}
+		requestOptions = append(requestOptions, marketOptions(market)...)
+
+		page, err := client.getAPIClient().GetAlbumTracks(ctx, spotifyLib.ID(id), requestOptions...)
+		if err != nil {
+			return nil, notFoundError(err)
+		}
+		pages++
+
+		for _, track := range page.Tracks {
+			tracklist.Tracks = append(tracklist.Tracks, toSimpleTrack(track))
+		}
+		tracklist.Total = page.Total
+
+		if page.Next == "" || len(page.Tracks) == 0 {
+			break
+		}
+	}
+	tracklist.Truncated = len(tracklist.Tracks) < tracklist.Total
+
+	span.SetAttributes(
+		attribute.Int("pages", pages),
+		attribute.Int("total", tracklist.Total),
+	)
+
+	return tracklist, nil
+}
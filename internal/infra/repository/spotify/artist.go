@@ -0,0 +1,123 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	spotifyLib "github.com/zmb3/spotify/v2"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var albumGroups = map[string]spotifyLib.AlbumType{
+	"album":       spotifyLib.AlbumTypeAlbum,
+	"single":      spotifyLib.AlbumTypeSingle,
+	"appears_on":  spotifyLib.AlbumTypeAppearsOn,
+	"compilation": spotifyLib.AlbumTypeCompilation,
+}
+
+// GetArtistTopTracks returns the 10 most popular tracks of an artist in the
+// market.
+func (client *SpotifyClient) GetArtistTopTracks(ctx context.Context, id string, market string) ([]domain.Item, error) {
+	ctx, span := client.tracer.Start(ctx, "SpotifyClient.GetArtistTopTracks")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("id", id),
+		attribute.String("market", market),
+	)
+
+	err := client.RenewTokenIfNeeded(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client.RenewTokenIfNeeded: %w", err)
+	}
+
+	tracks, err := client.getAPIClient().GetArtistsTopTracks(ctx, spotifyLib.ID(id), market)
+	if err != nil {
+		return nil, notFoundError(err)
+	}
+
+	items := make([]domain.Item, 0, len(tracks))
+	for _, track := range tracks {
+		items = append(items, toTrack(track))
+	}
+	return items, nil
+}
+
+// GetArtistAlbums returns a page of the albums of an artist, only including
+// the given groups (album, single, appears_on or compilation) if any.
+func (client *SpotifyClient) GetArtistAlbums(ctx context.Context, id string, includeGroups []string, opts appspotify.SearchOptions) (*appspotify.SearchPage, error) {
+	ctx, span := client.tracer.Start(ctx, "SpotifyClient.GetArtistAlbums")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("id", id),
+		attribute.StringSlice("include_groups", includeGroups),
+		attribute.Int("limit", opts.Limit),
+		attribute.Int("offset", opts.Offset),
+		attribute.String("market", opts.Market),
+	)
+
+	var groups []spotifyLib.AlbumType
+	for _, group := range includeGroups {
+		albumType, ok := albumGroups[group]
+		if !ok {
+			return nil, fmt.Errorf("unsupported album group: %s", group)
+		}
+		groups = append(groups, albumType)
+	}
+
+	err := client.RenewTokenIfNeeded(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client.RenewTokenIfNeeded: %w", err)
+	}
+
+	requestOptions := []spotifyLib.RequestOption{
+		spotifyLib.Limit(opts.Limit),
+		spotifyLib.Offset(opts.Offset),
+	}
+	requestOptions = append(requestOptions, marketOptions(opts.Market)...)
+
+	albums, err := client.getAPIClient().GetArtistAlbums(ctx, spotifyLib.ID(id), groups, requestOptions...)
+	if err != nil {
+		return nil, notFoundError(err)
+	}
+
+	return albumPage(albums, opts), nil
+}
+
+// GetRelatedArtists returns up to 20 artists similar to an artist.
+func (client *SpotifyClient) GetRelatedArtists(ctx context.Context, id string) ([]domain.Item, error) {
+	ctx, span := client.tracer.Start(ctx, "SpotifyClient.GetRelatedArtists")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("id", id))
+
+	err := client.RenewTokenIfNeeded(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client.RenewTokenIfNeeded: %w", err)
+	}
+
+	artists, err := client.getAPIClient().GetRelatedArtists(ctx, spotifyLib.ID(id))
+	if err != nil {
+		return nil, notFoundError(err)
+	}
+
+	items := make([]domain.Item, 0, len(artists))
+	for _, artist := range artists {
+		items = append(items, toArtist(artist))
+	}
+	return items, nil
+}
+
+// notFoundError reports unknown artists and albums as appspotify.ErrNotFound.
+func notFoundError(err error) error {
+	var spotifyErr spotifyLib.Error
+	if errors.As(err, &spotifyErr) && spotifyErr.Status == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", appspotify.ErrNotFound, spotifyErr.Message)
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
+	spotifyLib "github.com/zmb3/spotify/v2"
+)
+
+const apiBaseURL = "https://api.spotify.com/v1/"
+
+type Author struct {
+	Name string `json:"name"`
+}
+
+type Narrator struct {
+	Name string `json:"name"`
+}
+
+// SimpleAudiobook mirrors Spotify's simplified audiobook object, which the
+// zmb3 library does not model.
+type SimpleAudiobook struct {
+	Authors          []Author               `json:"authors"`
+	AvailableMarkets []string               `json:"available_markets"`
+	Copyrights       []spotifyLib.Copyright `json:"copyrights"`
+	Description      string                 `json:"description"`
+	Edition          string                 `json:"edition"`
+	Explicit         bool                   `json:"explicit"`
+	ExternalURLs     map[string]string      `json:"external_urls"`
+	Href             string                 `json:"href"`
+	ID               spotifyLib.ID          `json:"id"`
+	Images           []spotifyLib.Image     `json:"images"`
+	Languages        []string               `json:"languages"`
+	MediaType        string                 `json:"media_type"`
+	Name             string                 `json:"name"`
+	Narrators        []Narrator             `json:"narrators"`
+	Publisher        string                 `json:"publisher"`
+	Type             string                 `json:"type"`
+	URI              spotifyLib.URI         `json:"uri"`
+	TotalChapters    int                    `json:"total_chapters"`
+}
+
+type simpleAudiobookPage struct {
+	Total int               `json:"total"`
+	Items []SimpleAudiobook `json:"items"`
+}
+
+// searchAudiobooks calls the search endpoint directly, since the zmb3
+// library has no audiobook search type.
+func (client *SpotifyClient) searchAudiobooks(ctx context.Context, query string, opts appspotify.SearchOptions) (*appspotify.SearchPage, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("type", "audiobook")
+	params.Set("limit", strconv.Itoa(opts.Limit))
+	params.Set("offset", strconv.Itoa(opts.Offset))
+	if opts.Market != "" {
+		params.Set("market", opts.Market)
+	}
+	if opts.Locale != "" {
+		params.Set("locale", opts.Locale)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiBaseURL+"search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.getHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiError struct {
+			Error spotifyLib.Error `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiError); err != nil || apiError.Error.Message == "" {
+			return nil, fmt.Errorf("spotify: HTTP %d", resp.StatusCode)
+		}
+		return nil, apiError.Error
+	}
+
+	var results struct {
+		Audiobooks *simpleAudiobookPage `json:"audiobooks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("decoding audiobook search results: %w", err)
+	}
+
+	var items []domain.Item
+	var total int
+
+	if results.Audiobooks != nil {
+		total = results.Audiobooks.Total
+		for _, audiobook := range results.Audiobooks.Items {
+			if audiobook.ID == "" {
+				continue
+			}
+			items = append(items, toAudiobook(audiobook))
+		}
+	}
+
+	return appspotify.NewSearchPage(items, total, opts), nil
+}
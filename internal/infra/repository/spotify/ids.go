@@ -0,0 +1,132 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	spotifyLib "github.com/zmb3/spotify/v2"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Maximum number of IDs per call, as documented by Spotify.
+const (
+	maxArtistIDs = 50
+	maxAlbumIDs  = 20
+	maxTrackIDs  = 50
+)
+
+// GetArtists returns the artists in the same order as the IDs, with nil for
+// unknown IDs. The IDs are split into as many calls as needed.
+func (client *SpotifyClient) GetArtists(ctx context.Context, ids []string) ([]domain.Item, error) {
+	ctx, span := client.tracer.Start(ctx, "SpotifyClient.GetArtists")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("ids", len(ids)))
+
+	return client.getByIDs(ctx, ids, maxArtistIDs, func(ids []spotifyLib.ID) ([]domain.Item, error) {
+		artists, err := client.getAPIClient().GetArtists(ctx, ids...)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]domain.Item, len(artists))
+		for i, artist := range artists {
+			if artist != nil {
+				items[i] = toArtist(*artist)
+			}
+		}
+		return items, nil
+	})
+}
+
+// GetAlbums returns the albums in the same order as the IDs, with nil for
+// unknown IDs. The IDs are split into as many calls as needed.
+func (client *SpotifyClient) GetAlbums(ctx context.Context, ids []string, market string) ([]domain.Item, error) {
+	ctx, span := client.tracer.Start(ctx, "SpotifyClient.GetAlbums")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("ids", len(ids)),
+		attribute.String("market", market),
+	)
+
+	return client.getByIDs(ctx, ids, maxAlbumIDs, func(ids []spotifyLib.ID) ([]domain.Item, error) {
+		albums, err := client.getAPIClient().GetAlbums(ctx, ids, marketOptions(market)...)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]domain.Item, len(albums))
+		for i, album := range albums {
+			if album != nil {
+				items[i] = toFullAlbum(*album)
+			}
+		}
+		return items, nil
+	})
+}
+
+// GetTracks returns the tracks in the same order as the IDs, with nil for
+// unknown IDs. The IDs are split into as many calls as needed.
+func (client *SpotifyClient) GetTracks(ctx context.Context, ids []string, market string) ([]domain.Item, error) {
+	ctx, span := client.tracer.Start(ctx, "SpotifyClient.GetTracks")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("ids", len(ids)),
+		attribute.String("market", market),
+	)
+
+	return client.getByIDs(ctx, ids, maxTrackIDs, func(ids []spotifyLib.ID) ([]domain.Item, error) {
+		tracks, err := client.getAPIClient().GetTracks(ctx, ids, marketOptions(market)...)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]domain.Item, len(tracks))
+		for i, track := range tracks {
+			if track != nil {
+				items[i] = toTrack(*track)
+			}
+		}
+		return items, nil
+	})
+}
+
+// getByIDs calls get with chunks of at most maxIDs IDs, and concatenates the
+// results.
+func (client *SpotifyClient) getByIDs(ctx context.Context, ids []string, maxIDs int, get func(ids []spotifyLib.ID) ([]domain.Item, error)) ([]domain.Item, error) {
+	err := client.RenewTokenIfNeeded(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client.RenewTokenIfNeeded: %w", err)
+	}
+
+	items := make([]domain.Item, 0, len(ids))
+	for start := 0; start < len(ids); start += maxIDs {
+		end := min(start+maxIDs, len(ids))
+
+		chunk := make([]spotifyLib.ID, 0, end-start)
+		for _, id := range ids[start:end] {
+			chunk = append(chunk, spotifyLib.ID(id))
+		}
+
+		chunkItems, err := get(chunk)
+		if err != nil {
+			return nil, err
+		}
+		if len(chunkItems) != len(chunk) {
+			return nil, fmt.Errorf("spotify returned %d items for %d ids", len(chunkItems), len(chunk))
+		}
+		items = append(items, chunkItems...)
+	}
+
+	return items, nil
+}
+
+func marketOptions(market string) []spotifyLib.RequestOption {
+	if market == "" {
+		return nil
+	}
+	return []spotifyLib.RequestOption{spotifyLib.Market(market)}
+}
@@ -0,0 +1,175 @@
+package spotify
+
+import (
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	spotifyLib "github.com/zmb3/spotify/v2"
+)
+
+// This file maps the zmb3 library objects to the proxy's domain objects.
+
+func toObject(objectType string, id spotifyLib.ID, name string, uri spotifyLib.URI, externalURLs map[string]string) domain.Object {
+	return domain.Object{
+		ID:           string(id),
+		Type:         objectType,
+		Name:         name,
+		URI:          string(uri),
+		ExternalURLs: externalURLs,
+	}
+}
+
+func toImages(images []spotifyLib.Image) []domain.Image {
+	result := make([]domain.Image, 0, len(images))
+	for _, image := range images {
+		result = append(result, domain.Image{
+			URL:    image.URL,
+			Height: image.Height,
+			Width:  image.Width,
+		})
+	}
+	return result
+}
+
+func toSimpleArtists(artists []spotifyLib.SimpleArtist) []domain.SimpleArtist {
+	result := make([]domain.SimpleArtist, 0, len(artists))
+	for _, artist := range artists {
+		result = append(result, domain.SimpleArtist{
+			Object: toObject("artist", artist.ID, artist.Name, artist.URI, artist.ExternalURLs),
+		})
+	}
+	return result
+}
+
+func toArtist(artist spotifyLib.FullArtist) *domain.Artist {
+	return &domain.Artist{
+		Object:     toObject("artist", artist.ID, artist.Name, artist.URI, artist.ExternalURLs),
+		Genres:     artist.Genres,
+		Images:     toImages(artist.Images),
+		Popularity: artist.Popularity,
+		Followers:  domain.Followers{Total: int(artist.Followers.Count)},
+	}
+}
+
+func toSimpleAlbum(album spotifyLib.SimpleAlbum) domain.SimpleAlbum {
+	return domain.SimpleAlbum{
+		Object:               toObject("album", album.ID, album.Name, album.URI, album.ExternalURLs),
+		AlbumType:            album.AlbumType,
+		Artists:              toSimpleArtists(album.Artists),
+		Images:               toImages(album.Images),
+		ReleaseDate:          album.ReleaseDate,
+		ReleaseDatePrecision: album.ReleaseDatePrecision,
+		AvailableMarkets:     album.AvailableMarkets,
+	}
+}
+
+func toAlbum(album spotifyLib.SimpleAlbum) *domain.Album {
+	return &domain.Album{
+		SimpleAlbum: toSimpleAlbum(album),
+	}
+}
+
+func toFullAlbum(album spotifyLib.FullAlbum) *domain.Album {
+	return &domain.Album{
+		SimpleAlbum: toSimpleAlbum(album.SimpleAlbum),
+		Popularity:  album.Popularity,
+		ExternalIDs: album.ExternalIDs,
+	}
+}
+
+func toTrack(track spotifyLib.FullTrack) *domain.Track {
+	return &domain.Track{
+		Object:           toObject("track", track.ID, track.Name, track.URI, track.ExternalURLs),
+		Album:            toSimpleAlbum(track.Album),
+		Artists:          toSimpleArtists(track.Artists),
+		DiscNumber:       track.DiscNumber,
+		TrackNumber:      track.TrackNumber,
+		DurationMs:       track.Duration,
+		Explicit:         track.Explicit,
+		Popularity:       track.Popularity,
+		PreviewURL:       track.PreviewURL,
+		ExternalIDs:      track.ExternalIDs,
+		AvailableMarkets: track.AvailableMarkets,
+	}
+}
+
+// toSimpleTrack maps the tracks of an album, which don't have an album nor a
+// popularity.
+func toSimpleTrack(track spotifyLib.SimpleTrack) *domain.Track {
+	return &domain.Track{
+		Object:           toObject("track", track.ID, track.Name, track.URI, track.ExternalURLs),
+		Artists:          toSimpleArtists(track.Artists),
+		DiscNumber:       track.DiscNumber,
+		TrackNumber:      track.TrackNumber,
+		DurationMs:       track.Duration,
+		Explicit:         track.Explicit,
+		PreviewURL:       track.PreviewURL,
+		AvailableMarkets: track.AvailableMarkets,
+	}
+}
+
+func toPlaylist(playlist spotifyLib.SimplePlaylist) *domain.Playlist {
+	return &domain.Playlist{
+		Object:      toObject("playlist", playlist.ID, playlist.Name, playlist.URI, playlist.ExternalURLs),
+		Description: playlist.Description,
+		Owner: domain.User{
+			ID:          playlist.Owner.ID,
+			DisplayName: playlist.Owner.DisplayName,
+		},
+		Images:        toImages(playlist.Images),
+		Collaborative: playlist.Collaborative,
+		Public:        playlist.IsPublic,
+		TotalTracks:   int(playlist.Tracks.Total),
+	}
+}
+
+func toShow(show spotifyLib.FullShow) *domain.Show {
+	return &domain.Show{
+		Object:           toObject("show", show.ID, show.Name, show.URI, show.ExternalURLs),
+		Description:      show.Description,
+		Publisher:        show.Publisher,
+		Images:           toImages(show.Images),
+		Explicit:         show.Explicit,
+		Languages:        show.Languages,
+		MediaType:        show.MediaType,
+		AvailableMarkets: show.AvailableMarkets,
+	}
+}
+
+func toEpisode(episode spotifyLib.EpisodePage) *domain.Episode {
+	return &domain.Episode{
+		Object:               toObject("episode", episode.ID, episode.Name, episode.URI, episode.ExternalURLs),
+		Description:          episode.Description,
+		Images:               toImages(episode.Images),
+		DurationMs:           episode.Duration_ms,
+		Explicit:             episode.Explicit,
+		Languages:            episode.Languages,
+		ReleaseDate:          episode.ReleaseDate,
+		ReleaseDatePrecision: episode.ReleaseDatePrecision,
+		AudioPreviewURL:      episode.AudioPreviewURL,
+	}
+}
+
+func toAudiobook(audiobook SimpleAudiobook) *domain.Audiobook {
+	authors := make([]string, 0, len(audiobook.Authors))
+	for _, author := range audiobook.Authors {
+		authors = append(authors, author.Name)
+	}
+	narrators := make([]string, 0, len(audiobook.Narrators))
+	for _, narrator := range audiobook.Narrators {
+		narrators = append(narrators, narrator.Name)
+	}
+
+	return &domain.Audiobook{
+		Object:           toObject("audiobook", audiobook.ID, audiobook.Name, audiobook.URI, audiobook.ExternalURLs),
+		Authors:          authors,
+		Narrators:        narrators,
+		Publisher:        audiobook.Publisher,
+		Description:      audiobook.Description,
+		Edition:          audiobook.Edition,
+		Images:           toImages(audiobook.Images),
+		Explicit:         audiobook.Explicit,
+		Languages:        audiobook.Languages,
+		MediaType:        audiobook.MediaType,
+		TotalChapters:    audiobook.TotalChapters,
+		AvailableMarkets: audiobook.AvailableMarkets,
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/angristan/spotify-search-proxy/internal/app/domain"
+	appspotify "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
 	spotifyLib "github.com/zmb3/spotify/v2"
 	spotifyauth "github.com/zmb3/spotify/v2/auth"
 	"go.opentelemetry.io/otel/attribute"
@@ -37,10 +39,11 @@ func NewSpotifyClientConfig(
 }
 
 type SpotifyClient struct {
-	tracer    trace.Tracer
-	apiClient *spotifyLib.Client
-	config    clientcredentials.Config
-	mu        sync.RWMutex
+	tracer     trace.Tracer
+	apiClient  *spotifyLib.Client
+	httpClient *http.Client
+	config     clientcredentials.Config
+	mu         sync.RWMutex
 }
 
 func New(ctx context.Context, config *SpotifyClientConfig) (*SpotifyClient, error) {
@@ -61,87 +64,234 @@ func New(ctx context.Context, config *SpotifyClientConfig) (*SpotifyClient, erro
 	APIClient := spotifyLib.New(httpClient)
 
 	return &SpotifyClient{
-		apiClient: APIClient,
-		tracer:    config.tracer,
-		config:    spotifyConfig,
+		apiClient:  APIClient,
+		httpClient: httpClient,
+		tracer:     config.tracer,
+		config:     spotifyConfig,
 	}, nil
 }
 
 type SearchType int
 
 const (
-	SearchTypeAlbum  SearchType = 1 << iota
-	SearchTypeArtist            = 1 << iota
-	SearchTypeTrack             = 1 << iota
+	SearchTypeAlbum     SearchType = 1 << iota
+	SearchTypeArtist               = 1 << iota
+	SearchTypeTrack                = 1 << iota
+	SearchTypePlaylist             = 1 << iota
+	SearchTypeShow                 = 1 << iota
+	SearchTypeEpisode              = 1 << iota
+	SearchTypeAudiobook            = 1 << iota
 )
 
+// ToSpotifySearchType returns the equivalent zmb3 search type mask. Audiobooks
+// are not supported by the library, so they are left out and searched
+// separately.
 func (st SearchType) ToSpotifySearchType() spotifyLib.SearchType {
-	switch st {
-	case SearchTypeAlbum:
-		return spotifyLib.SearchTypeAlbum
-	case SearchTypeArtist:
-		return spotifyLib.SearchTypeArtist
-	case SearchTypeTrack:
-		return spotifyLib.SearchTypeTrack
+	var spotifySearchType spotifyLib.SearchType
+
+	if st&SearchTypeAlbum != 0 {
+		spotifySearchType |= spotifyLib.SearchTypeAlbum
+	}
+	if st&SearchTypeArtist != 0 {
+		spotifySearchType |= spotifyLib.SearchTypeArtist
+	}
+	if st&SearchTypeTrack != 0 {
+		spotifySearchType |= spotifyLib.SearchTypeTrack
+	}
+	if st&SearchTypePlaylist != 0 {
+		spotifySearchType |= spotifyLib.SearchTypePlaylist
+	}
+	if st&SearchTypeShow != 0 {
+		spotifySearchType |= spotifyLib.SearchTypeShow
+	}
+	if st&SearchTypeEpisode != 0 {
+		spotifySearchType |= spotifyLib.SearchTypeEpisode
 	}
 
-	return spotifyLib.SearchTypeArtist // TODO
+	return spotifySearchType
 }
 
-func (client *SpotifyClient) Search(ctx context.Context, query string, qType string) (any, error) {
-	ctx, span := client.tracer.Start(ctx, "SpotifyClient.Search")
-	defer span.End()
-
-	var spotifyQueryType SearchType
+func parseSearchType(qType string) (SearchType, error) {
 	switch qType {
 	case "artist":
-		spotifyQueryType = SearchTypeArtist
+		return SearchTypeArtist, nil
 	case "album":
-		spotifyQueryType = SearchTypeAlbum
+		return SearchTypeAlbum, nil
 	case "track":
-		spotifyQueryType = SearchTypeTrack
-	default:
-		return nil, fmt.Errorf("unsupported search type: %s", qType)
+		return SearchTypeTrack, nil
+	case "playlist":
+		return SearchTypePlaylist, nil
+	case "show":
+		return SearchTypeShow, nil
+	case "episode":
+		return SearchTypeEpisode, nil
+	case "audiobook":
+		return SearchTypeAudiobook, nil
 	}
 
-	spotifyQueryType2 := spotifyQueryType.ToSpotifySearchType()
+	return 0, fmt.Errorf("unsupported search type: %s", qType)
+}
+
+// Search looks up the query for all the given types in a single call to
+// Spotify, and returns a page of results per type.
+func (client *SpotifyClient) Search(ctx context.Context, query string, qTypes []string, opts appspotify.SearchOptions) (map[string]*appspotify.SearchPage, error) {
+	ctx, span := client.tracer.Start(ctx, "SpotifyClient.Search")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.StringSlice("types", qTypes),
+		attribute.Int("limit", opts.Limit),
+		attribute.Int("offset", opts.Offset),
+		attribute.String("market", opts.Market),
+		attribute.String("locale", opts.Locale),
+	)
+
+	var spotifyQueryType SearchType
+	for _, qType := range qTypes {
+		searchType, err := parseSearchType(qType)
+		if err != nil {
+			return nil, err
+		}
+		spotifyQueryType |= searchType
+	}
 
 	err := client.RenewTokenIfNeeded(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("client.RenewTokenIfNeeded: %w", err)
 	}
 
+	pages := make(map[string]*appspotify.SearchPage, len(qTypes))
+
+	if spotifyQueryType&SearchTypeAudiobook != 0 {
+		page, err := client.searchAudiobooks(ctx, query, opts)
+		if err != nil {
+			return nil, err
+		}
+		pages["audiobook"] = page
+	}
+
+	spotifyQueryType2 := spotifyQueryType.ToSpotifySearchType()
+	if spotifyQueryType2 == 0 {
+		return pages, nil
+	}
+
 	apiClient := client.getAPIClient()
-	results, err := apiClient.Search(ctx, query, spotifyQueryType2)
+	results, err := apiClient.Search(ctx, query, spotifyQueryType2, searchRequestOptions(opts)...)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: better way to do it?
-	var result any
+	if spotifyQueryType&SearchTypeArtist != 0 {
+		pages["artist"] = artistPage(results.Artists, opts)
+	}
+	if spotifyQueryType&SearchTypeAlbum != 0 {
+		pages["album"] = albumPage(results.Albums, opts)
+	}
+	if spotifyQueryType&SearchTypeTrack != 0 {
+		pages["track"] = trackPage(results.Tracks, opts)
+	}
+	if spotifyQueryType&SearchTypePlaylist != 0 {
+		pages["playlist"] = playlistPage(results.Playlists, opts)
+	}
+	if spotifyQueryType&SearchTypeShow != 0 {
+		pages["show"] = showPage(results.Shows, opts)
+	}
+	if spotifyQueryType&SearchTypeEpisode != 0 {
+		pages["episode"] = episodePage(results.Episodes, opts)
+	}
+
+	return pages, nil
+}
 
-	switch spotifyQueryType2 {
-	case spotifyLib.SearchTypeArtist:
-		if results.Artists != nil {
-			if len(results.Artists.Artists) > 0 {
-				result = results.Artists.Artists[0]
-			}
-		}
-	case spotifyLib.SearchTypeAlbum:
-		if results.Albums != nil {
-			if len(results.Albums.Albums) > 0 {
-				result = results.Albums.Albums[0]
-			}
-		}
-	case spotifyLib.SearchTypeTrack:
-		if results.Tracks != nil {
-			if len(results.Tracks.Tracks) > 0 {
-				result = results.Tracks.Tracks[0]
-			}
+func searchRequestOptions(opts appspotify.SearchOptions) []spotifyLib.RequestOption {
+	requestOptions := []spotifyLib.RequestOption{
+		spotifyLib.Limit(opts.Limit),
+		spotifyLib.Offset(opts.Offset),
+	}
+	if opts.Market != "" {
+		requestOptions = append(requestOptions, spotifyLib.Market(opts.Market))
+	}
+	if opts.Locale != "" {
+		requestOptions = append(requestOptions, spotifyLib.Locale(opts.Locale))
+	}
+	return requestOptions
+}
+
+func artistPage(results *spotifyLib.FullArtistPage, opts appspotify.SearchOptions) *appspotify.SearchPage {
+	if results == nil {
+		return appspotify.NewSearchPage(nil, 0, opts)
+	}
+
+	items := make([]domain.Item, 0, len(results.Artists))
+	for _, artist := range results.Artists {
+		items = append(items, toArtist(artist))
+	}
+	return appspotify.NewSearchPage(items, results.Total, opts)
+}
+
+func albumPage(results *spotifyLib.SimpleAlbumPage, opts appspotify.SearchOptions) *appspotify.SearchPage {
+	if results == nil {
+		return appspotify.NewSearchPage(nil, 0, opts)
+	}
+
+	items := make([]domain.Item, 0, len(results.Albums))
+	for _, album := range results.Albums {
+		items = append(items, toAlbum(album))
+	}
+	return appspotify.NewSearchPage(items, results.Total, opts)
+}
+
+func trackPage(results *spotifyLib.FullTrackPage, opts appspotify.SearchOptions) *appspotify.SearchPage {
+	if results == nil {
+		return appspotify.NewSearchPage(nil, 0, opts)
+	}
+
+	items := make([]domain.Item, 0, len(results.Tracks))
+	for _, track := range results.Tracks {
+		items = append(items, toTrack(track))
+	}
+	return appspotify.NewSearchPage(items, results.Total, opts)
+}
+
+func playlistPage(results *spotifyLib.SimplePlaylistPage, opts appspotify.SearchOptions) *appspotify.SearchPage {
+	if results == nil {
+		return appspotify.NewSearchPage(nil, 0, opts)
+	}
+
+	items := make([]domain.Item, 0, len(results.Playlists))
+	for _, playlist := range results.Playlists {
+		// Spotify sometimes returns null entries for playlists
+		// that were removed since they were indexed.
+		if playlist.ID == "" {
+			continue
 		}
+		items = append(items, toPlaylist(playlist))
+	}
+	return appspotify.NewSearchPage(items, results.Total, opts)
+}
+
+func showPage(results *spotifyLib.SimpleShowPage, opts appspotify.SearchOptions) *appspotify.SearchPage {
+	if results == nil {
+		return appspotify.NewSearchPage(nil, 0, opts)
+	}
+
+	items := make([]domain.Item, 0, len(results.Shows))
+	for _, show := range results.Shows {
+		items = append(items, toShow(show))
+	}
+	return appspotify.NewSearchPage(items, results.Total, opts)
+}
+
+func episodePage(results *spotifyLib.SimpleEpisodePage, opts appspotify.SearchOptions) *appspotify.SearchPage {
+	if results == nil {
+		return appspotify.NewSearchPage(nil, 0, opts)
 	}
 
-	return result, nil
+	items := make([]domain.Item, 0, len(results.Episodes))
+	for _, episode := range results.Episodes {
+		items = append(items, toEpisode(episode))
+	}
+	return appspotify.NewSearchPage(items, results.Total, opts)
 }
 
 // Check if the token expires soon, and if so recreates an API client with a new token
@@ -168,8 +318,7 @@ func (client *SpotifyClient) RenewTokenIfNeeded(ctx context.Context) error {
 		return fmt.Errorf("client.config.Token: %w", err)
 	}
 
-	httpClient := spotifyauth.New().Client(ctx, token)
-	client.setAPIClient(spotifyLib.New(httpClient))
+	client.setAPIClient(spotifyauth.New().Client(ctx, token))
 
 	span.AddEvent("Token refreshed")
 
@@ -182,8 +331,15 @@ func (client *SpotifyClient) getAPIClient() *spotifyLib.Client {
 	return client.apiClient
 }
 
-func (client *SpotifyClient) setAPIClient(apiClient *spotifyLib.Client) {
+func (client *SpotifyClient) getHTTPClient() *http.Client {
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+	return client.httpClient
+}
+
+func (client *SpotifyClient) setAPIClient(httpClient *http.Client) {
 	client.mu.Lock()
 	defer client.mu.Unlock()
-	client.apiClient = apiClient
+	client.httpClient = httpClient
+	client.apiClient = spotifyLib.New(httpClient)
 }
@@ -3,16 +3,21 @@ package main
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"net/http/httptrace"
+	"os"
+	"strings"
 
 	spotifyService "github.com/angristan/spotify-search-proxy/internal/app/services/spotify"
 	server "github.com/angristan/spotify-search-proxy/internal/infra/http"
 	spotifyHandler "github.com/angristan/spotify-search-proxy/internal/infra/http/handlers/spotify"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/memory"
 	redisCache "github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/redis"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/tiered"
+	"github.com/angristan/spotify-search-proxy/internal/infra/repository/shortlink"
 	spotifyClient "github.com/angristan/spotify-search-proxy/internal/infra/repository/spotify"
-	"github.com/redis/go-redis/extra/redisotel/v9"
-	goRedis "github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -31,6 +36,13 @@ func main() {
 
 	ctx := context.Background()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate-cache" {
+		if err := migrateCache(ctx, config, os.Args[2:]); err != nil {
+			logrus.WithError(err).Fatal("Failed to migrate cache")
+		}
+		return
+	}
+
 	var tracerProvider *sdktrace.TracerProvider
 	var tracer trace.Tracer
 
@@ -54,6 +66,22 @@ func main() {
 		tracer = otel.Tracer("spotify-search-proxy")
 	}
 
+	if config.MetricsEnabled && config.OTLPEndpoint != "" {
+		metricExporter, err := newMetricExporter(ctx, config.OTLPEndpoint)
+		if err != nil {
+			logrus.Fatalf("failed to initialize metric exporter: %v", err)
+		}
+
+		meterProvider, err := newMeterProvider(metricExporter)
+		if err != nil {
+			logrus.Fatalf("failed to create meter provider: %v", err)
+		}
+
+		defer func() { _ = meterProvider.Shutdown(ctx) }()
+
+		otel.SetMeterProvider(meterProvider)
+	}
+
 	tracedHTTPClient := &http.Client{
 		Transport: otelhttp.NewTransport(
 			http.DefaultTransport,
@@ -62,18 +90,50 @@ func main() {
 			})),
 	}
 
-	redisClient := goRedis.NewClient(&goRedis.Options{
-		Addr:     config.RedisAddr,
-		Password: config.RedisPassword,
-		Username: config.RedisUsername,
+	cacheBackend, err := cache.New(ctx, config.CacheBackend, cache.Config{
+		Tracer:        tracer,
+		RedisAddr:     config.RedisAddr,
+		RedisUsername: config.RedisUsername,
+		RedisPassword: config.RedisPassword,
+		RedisCompression: redisCache.Compression{
+			Algorithm: config.CacheCompression,
+			MinSize:   config.CacheCompressionMinSize,
+		},
+		Memory: memory.Config{
+			MaxEntries: config.MemoryCacheMaxEntries,
+			MaxBytes:   config.MemoryCacheMaxBytes,
+		},
+		DiskPath: config.DiskCachePath,
 	})
-
-	err = redisotel.InstrumentTracing(redisClient)
 	if err != nil {
-		logrus.WithError(err).Fatal("Failed to instrument Redis tracing")
+		logrus.WithError(err).Fatal("Failed to create cache")
 	}
 
-	cache := redisCache.New(tracer, redisClient)
+	defer func() { _ = cacheBackend.Close() }()
+
+	serviceCache := cacheBackend.Cache
+	if config.L1CacheEnabled && cacheBackend.Invalidator != nil {
+		tieredCache := tiered.New(
+			tracer,
+			memory.New(tracer, memory.Config{
+				MaxEntries: config.L1CacheMaxEntries,
+				MaxBytes:   config.L1CacheMaxBytes,
+			}),
+			cacheBackend.Cache,
+			tiered.Config{
+				TTL:         config.L1CacheTTL,
+				Invalidator: cacheBackend.Invalidator,
+			},
+		)
+
+		go func() {
+			if err := tieredCache.Listen(ctx); err != nil {
+				logrus.WithError(err).Error("Failed to listen for cache invalidations")
+			}
+		}()
+
+		serviceCache = tieredCache
+	}
 
 	spotifyClientConfig := spotifyClient.NewSpotifyClientConfig(
 		config.SpotifyClientID,
@@ -87,11 +147,63 @@ func main() {
 		logrus.WithError(err).Fatal("Failed to create Spotify client")
 	}
 
-	spotifyService := spotifyService.New(tracer, spotifyClient, cache)
+	serviceConfig := spotifyService.Config{
+		Namespace:        config.CacheNamespace,
+		DefaultMarket:    config.DefaultMarket,
+		BatchConcurrency: config.BatchConcurrency,
+		MaxAlbumTracks:   config.MaxAlbumTracks,
+		SuggestDebounce:  config.SuggestDebounce,
+		TTLs: spotifyService.TTLPolicy{
+			Search:   config.SearchTTLs,
+			ID:       config.IDTTLs,
+			Resource: config.ResourceTTLs,
+			Negative: config.NegativeCacheTTL,
+			Jitter:   config.CacheTTLJitter,
+			Max:      config.MaxCacheTTL,
+			Stale:    config.StaleTTL,
+		},
+	}
+	if err := serviceConfig.TTLs.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid cache TTLs")
+	}
+	if len(config.QueryFoldDiacritics) > 0 {
+		serviceConfig.QueryNormalization = map[string]spotifyService.QueryNormalization{}
+		for _, searchType := range config.QueryFoldDiacritics {
+			normalization := spotifyService.DefaultQueryNormalization
+			normalization.FoldDiacritics = true
+			serviceConfig.QueryNormalization[strings.TrimSpace(searchType)] = normalization
+		}
+	}
+	if config.DistributedCoalescing {
+		serviceConfig.Locker = cacheBackend.Locker
+	}
+	if config.CacheJanitorInterval > 0 {
+		serviceConfig.Sweeper = cacheBackend.Sweeper
+	}
+	if config.ShortLinksEnabled {
+		serviceConfig.ShortLinkResolver = shortlink.New(tracer, tracedHTTPClient, config.ShortLinkTimeout)
+	}
+
+	spotifyService := spotifyService.New(tracer, spotifyClient, serviceCache, serviceConfig)
+
+	if err := spotifyService.RefreshGeneration(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to read cache generation")
+	}
+	go spotifyService.WatchGeneration(ctx)
+	if serviceConfig.Sweeper != nil {
+		go spotifyService.RunJanitor(ctx, config.CacheJanitorInterval)
+	}
 
 	spotifyHandler := spotifyHandler.New(tracer, spotifyService)
 
 	serverConfig := server.NewConfig(config.Port, false)
+	for _, cidr := range config.TrustedNetworks {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			logrus.WithError(err).Fatal("Invalid trusted network")
+		}
+		serverConfig.TrustedNetworks = append(serverConfig.TrustedNetworks, network)
+	}
 
 	httpServer, err := server.New(serverConfig, spotifyHandler)
 	if err != nil {
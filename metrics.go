@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"net/url"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func newMetricExporter(ctx context.Context, endpoint string) (metric.Exporter, error) {
+	if parsed, err := url.Parse(endpoint); err == nil && parsed.Scheme != "" {
+		opts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(parsed.Host),
+		}
+		// The path of the endpoint is the one of traces, metrics use the
+		// default one
+		if parsed.Scheme == "http" {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	}
+
+	return otlpmetrichttp.New(ctx,
+		otlpmetrichttp.WithInsecure(),
+		otlpmetrichttp.WithEndpoint(endpoint),
+	)
+}
+
+func newMeterProvider(metricExporter metric.Exporter) (*metric.MeterProvider, error) {
+	resource, err := newResource()
+	if err != nil {
+		return nil, err
+	}
+
+	return metric.NewMeterProvider(
+		metric.WithReader(metric.NewPeriodicReader(metricExporter)),
+		metric.WithResource(resource),
+	), nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	redisCache "github.com/angristan/spotify-search-proxy/internal/infra/repository/cache/redis"
+	goRedis "github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+// migrateCache compresses the values cached in Redis before compression was
+// enabled, or with another algorithm, according to CACHE_COMPRESSION.
+func migrateCache(ctx context.Context, config *Env, args []string) error {
+	flags := flag.NewFlagSet("migrate-cache", flag.ContinueOnError)
+	pattern := flags.String("pattern", config.CacheNamespace+":*", "keys to rewrite, as a SCAN pattern")
+	batchSize := flags.Int("batch-size", redisCache.DefaultMigrationBatchSize, "number of keys scanned at once")
+	dryRun := flags.Bool("dry-run", false, "only count the keys to rewrite")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if config.RedisAddr == "" {
+		return errors.New("REDIS_ADDR is required")
+	}
+	if config.CacheCompression == redisCache.CompressionNone {
+		return errors.New("compression is disabled by CACHE_COMPRESSION")
+	}
+
+	redisClient := goRedis.NewClient(&goRedis.Options{
+		Addr:     config.RedisAddr,
+		Password: config.RedisPassword,
+		Username: config.RedisUsername,
+	})
+	defer redisClient.Close()
+
+	cache, err := redisCache.New(otel.Tracer("spotify-search-proxy"), redisClient, redisCache.Config{
+		Compression: redisCache.Compression{
+			Algorithm: config.CacheCompression,
+			MinSize:   config.CacheCompressionMinSize,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	stats, err := cache.Recompress(ctx, redisCache.RecompressOptions{
+		Pattern:   *pattern,
+		BatchSize: *batchSize,
+		DryRun:    *dryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("recompress: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"scanned":      stats.Scanned,
+		"rewritten":    stats.Rewritten,
+		"bytes_before": stats.BytesBefore,
+		"bytes_after":  stats.BytesAfter,
+		"dry_run":      *dryRun,
+	}).Info("Cache migrated")
+	return nil
+}
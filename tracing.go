@@ -30,8 +30,8 @@ func newSpanExporter(ctx context.Context, endpoint string) (trace.SpanExporter,
 	)
 }
 
-func newTracerProvider(spanExporter trace.SpanExporter) (*trace.TracerProvider, error) {
-	resource, err := resource.New(context.Background(),
+func newResource() (*resource.Resource, error) {
+	return resource.New(context.Background(),
 		resource.WithFromEnv(),
 		resource.WithProcess(),
 		resource.WithOS(),
@@ -40,6 +40,10 @@ func newTracerProvider(spanExporter trace.SpanExporter) (*trace.TracerProvider,
 		resource.WithAttributes(semconv.ServiceName("spotify-search-proxy")),
 		resource.WithSchemaURL(semconv.SchemaURL),
 	)
+}
+
+func newTracerProvider(spanExporter trace.SpanExporter) (*trace.TracerProvider, error) {
+	resource, err := newResource()
 	if err != nil {
 		return nil, err
 	}